@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type CascadeConfig struct {
+	A string `env:"CASCADE_A"`
+	B string `env:"CASCADE_B"`
+	C string `env:"CASCADE_C"`
+	D string `env:"CASCADE_D"`
+	E string `env:"CASCADE_E"`
+}
+
+func (c *CascadeConfig) SetDefaults() {
+	c.E = "default"
+}
+
+func writeCascade(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestDotenvCascadePrecedence(t *testing.T) {
+	dir := writeCascade(t, map[string]string{
+		".env":                  "CASCADE_A=env\nCASCADE_B=env\nCASCADE_C=env\nCASCADE_D=env\n",
+		".env.staging":          "CASCADE_A=staging\nCASCADE_B=staging\nCASCADE_C=staging\n",
+		".env.local":            "CASCADE_A=local\nCASCADE_B=local\n",
+		".env.staging.local":    "CASCADE_A=staging.local\n",
+		".env.production":       "CASCADE_A=production\n",
+		".env.production.local": "CASCADE_B=production.local\n",
+	})
+
+	loader := New(
+		WithDotenvCascade("APP_ENV"),
+		WithEnvDir(dir),
+		WithoutEnvMutation(),
+		WithLookup(mapLookup(map[string]string{"APP_ENV": "staging"})),
+	)
+
+	var cfg CascadeConfig
+	provenance, err := loader.LoadExplain(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	expected := map[string]string{
+		"A": "staging.local",
+		"B": "local",
+		"C": "staging",
+		"D": "env",
+		"E": "default",
+	}
+	got := map[string]string{"A": cfg.A, "B": cfg.B, "C": cfg.C, "D": cfg.D, "E": cfg.E}
+	for field, want := range expected {
+		if got[field] != want {
+			t.Errorf("expected %s to be '%s', got '%s'", field, want, got[field])
+		}
+	}
+
+	origin, _ := provenance.Field("C")
+	if origin.File != filepath.Join(dir, ".env.staging") {
+		t.Errorf("expected C to come from .env.staging, got %s", origin)
+	}
+}
+
+func TestDotenvCascadeWithoutEnvironment(t *testing.T) {
+	dir := writeCascade(t, map[string]string{
+		".env":         "CASCADE_A=env\nCASCADE_B=env\n",
+		".env.local":   "CASCADE_A=local\n",
+		".env.staging": "CASCADE_B=staging\n",
+	})
+
+	var cfg CascadeConfig
+	err := New(WithDotenvCascade("APP_ENV"), WithEnvDir(dir), WithoutEnvMutation(), WithLookup(mapLookup(nil))).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.A != "local" {
+		t.Errorf("expected A to be 'local', got '%s'", cfg.A)
+	}
+	if cfg.B != "env" {
+		t.Errorf("expected B to be 'env', got '%s'", cfg.B)
+	}
+}
+
+func TestDotenvCascadeEnvironmentFromDotenv(t *testing.T) {
+	dir := writeCascade(t, map[string]string{
+		".env":      "DEPLOY_ENV=test\nCASCADE_A=env\n",
+		".env.test": "CASCADE_A=test\n",
+	})
+
+	var cfg CascadeConfig
+	err := New(WithDotenvCascade("DEPLOY_ENV"), WithEnvDir(dir), WithoutEnvMutation(), WithLookup(mapLookup(nil))).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.A != "test" {
+		t.Errorf("expected A to be 'test', got '%s'", cfg.A)
+	}
+}
+
+func TestWithEnvDirResolvesEnvFiles(t *testing.T) {
+	dir := writeCascade(t, map[string]string{"app.env": "CASCADE_A=dir\n"})
+
+	var cfg CascadeConfig
+	err := New(WithEnvFiles("app.env"), WithEnvDir(dir), WithoutEnvMutation(), WithLookup(mapLookup(nil))).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.A != "dir" {
+		t.Errorf("expected A to be 'dir', got '%s'", cfg.A)
+	}
+}
+
+func TestDotenvCascadeReferencesLowerFiles(t *testing.T) {
+	dir := writeCascade(t, map[string]string{
+		".env":         "CASCADE_HOST=localhost\nCASCADE_USER=app\n",
+		".env.staging": "CASCADE_HOST=db.staging\n",
+		".env.local":   "CASCADE_A=postgres://${CASCADE_USER}@${CASCADE_HOST}/app\nCASCADE_USER=dev\nCASCADE_B=${CASCADE_USER}\n",
+	})
+
+	var cfg CascadeConfig
+	err := New(
+		WithDotenvCascade("APP_ENV"),
+		WithEnvDir(dir),
+		WithoutEnvMutation(),
+		WithLookup(mapLookup(map[string]string{"APP_ENV": "staging"})),
+	).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.A != "postgres://app@db.staging/app" {
+		t.Errorf("expected A to be 'postgres://app@db.staging/app', got '%s'", cfg.A)
+	}
+	if cfg.B != "dev" {
+		t.Errorf("expected B to be 'dev', got '%s'", cfg.B)
+	}
+}
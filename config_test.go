@@ -51,7 +51,7 @@ func TestLoadMissingRequired(t *testing.T) {
 		t.Fatal("expected error for missing required field Name, got nil")
 	}
 
-	expectedErr := "field 'Name' is required"
+	expectedErr := "TEST_NAME: field 'Name' is required; TEST_NAME: field 'Name' must be at least 5 characters"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
@@ -71,21 +71,21 @@ func TestLoadValidationRules(t *testing.T) {
 			name:      "App", // less than min length 5
 			port:      9000,
 			expectErr: true,
-			errMsg:    "field 'Name' must be at least 5 characters",
+			errMsg:    "TEST_NAME: field 'Name' must be at least 5 characters",
 		},
 		{
 			desc:      "Port too low",
 			name:      "ValidApp",
 			port:      800, // below minimum 1024
 			expectErr: true,
-			errMsg:    "field 'Port' must be at least 1024",
+			errMsg:    "TEST_PORT: field 'Port' must be at least 1024",
 		},
 		{
 			desc:      "Port too high",
 			name:      "ValidApp",
 			port:      70000, // above maximum 65535
 			expectErr: true,
-			errMsg:    "field 'Port' must be at most 65535",
+			errMsg:    "TEST_PORT: field 'Port' must be at most 65535",
 		},
 		{
 			desc:      "Valid config using defaults",
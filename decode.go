@@ -0,0 +1,236 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decoder is implemented by types that decode themselves from a raw config
+// value. It takes precedence over encoding.TextUnmarshaler.
+type Decoder interface {
+	Decode(value string) error
+}
+
+var errUnsupportedType = errors.New("unsupported type")
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+	urlType      = reflect.TypeOf(url.URL{})
+)
+
+// setRaw decodes a value found by lookupSources: a string, or a list or
+// mapping from a structured source.
+func (l *Loader) setRaw(f reflect.Value, field reflect.StructField, value any) error {
+	switch v := value.(type) {
+	case string:
+		return l.setField(f, field, v)
+	case []string:
+		return l.setList(f, field, v)
+	case map[string]string:
+		return l.setEntries(f, field, v)
+	default:
+		return fmt.Errorf("cannot decode %T into %s", value, f.Type())
+	}
+}
+
+// setField decodes raw into f according to the field's kind. Slices and maps
+// are split on the field's sep tag (default ",") and map entries on its kvsep
+// tag (default ":"). time.Time values use the layout tag (default RFC3339).
+func (l *Loader) setField(f reflect.Value, field reflect.StructField, raw string) error {
+	if ok, err := l.decodeCustom(f, raw); ok {
+		return err
+	}
+
+	switch f.Kind() {
+	case reflect.Slice:
+		return l.setSlice(f, field, raw)
+	case reflect.Map:
+		return l.setMap(f, field, raw)
+	default:
+		return l.setValue(f, field, raw)
+	}
+}
+
+// setValue decodes a single value: a field, slice element, map key or map
+// value. Pointers are allocated and the value decoded into their target.
+func (l *Loader) setValue(f reflect.Value, field reflect.StructField, raw string) error {
+	if ok, err := l.decodeCustom(f, raw); ok {
+		return err
+	}
+
+	if f.Kind() == reflect.Ptr {
+		p := reflect.New(f.Type().Elem())
+		if err := l.setValue(p.Elem(), field, raw); err != nil {
+			return err
+		}
+		f.Set(p)
+		return nil
+	}
+
+	return setScalar(f, field, raw)
+}
+
+// decodeCustom decodes raw through a parser registered for f's type, or a
+// Decoder or encoding.TextUnmarshaler implemented by f's address. It reports
+// whether any of them applied.
+func (l *Loader) decodeCustom(f reflect.Value, raw string) (bool, error) {
+	if parse, ok := l.parser(f.Type()); ok {
+		return true, setParsed(f, parse, raw)
+	}
+
+	// time.Time implements encoding.TextUnmarshaler, but only for RFC3339;
+	// setScalar parses it with the field's layout tag instead.
+	if !f.CanAddr() || f.Type() == timeType {
+		return false, nil
+	}
+	switch d := f.Addr().Interface().(type) {
+	case Decoder:
+		return true, d.Decode(raw)
+	case encoding.TextUnmarshaler:
+		return true, d.UnmarshalText([]byte(raw))
+	}
+	return false, nil
+}
+
+func setParsed(f reflect.Value, parse ParserFunc, raw string) error {
+	parsed, err := parse(raw)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(parsed)
+	if !v.IsValid() || !v.Type().AssignableTo(f.Type()) {
+		return fmt.Errorf("parser for %s returned %T", f.Type(), parsed)
+	}
+	f.Set(v)
+	return nil
+}
+
+func setScalar(f reflect.Value, field reflect.StructField, raw string) error {
+	switch f.Type() {
+	case durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := time.Parse(tagOrDefault(field, "layout", time.RFC3339), raw)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	case urlType:
+		u, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intVal, err := strconv.ParseInt(raw, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(intVal)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintVal, err := strconv.ParseUint(raw, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(uintVal)
+	case reflect.Float32, reflect.Float64:
+		floatVal, err := strconv.ParseFloat(raw, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(floatVal)
+	case reflect.Bool:
+		boolVal, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		f.SetBool(boolVal)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, f.Kind())
+	}
+	return nil
+}
+
+func (l *Loader) setSlice(f reflect.Value, field reflect.StructField, raw string) error {
+	parts := strings.Split(raw, tagOrDefault(field, "sep", ","))
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return l.setList(f, field, parts)
+}
+
+// setList decodes each of items into an element of the slice f.
+func (l *Loader) setList(f reflect.Value, field reflect.StructField, items []string) error {
+	if f.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot decode a list into %s", f.Type())
+	}
+	slice := reflect.MakeSlice(f.Type(), len(items), len(items))
+	for i, item := range items {
+		if err := l.setValue(slice.Index(i), field, item); err != nil {
+			return err
+		}
+	}
+	f.Set(slice)
+	return nil
+}
+
+func (l *Loader) setMap(f reflect.Value, field reflect.StructField, raw string) error {
+	kvsep := tagOrDefault(field, "kvsep", ":")
+	entries := map[string]string{}
+	for _, entry := range strings.Split(raw, tagOrDefault(field, "sep", ",")) {
+		key, value, ok := strings.Cut(entry, kvsep)
+		if !ok {
+			return fmt.Errorf("invalid map entry '%s': missing '%s'", entry, kvsep)
+		}
+		entries[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return l.setEntries(f, field, entries)
+}
+
+// setEntries decodes each key and value of entries into the map f.
+func (l *Loader) setEntries(f reflect.Value, field reflect.StructField, entries map[string]string) error {
+	if f.Kind() != reflect.Map {
+		return fmt.Errorf("cannot decode a mapping into %s", f.Type())
+	}
+	t := f.Type()
+	m := reflect.MakeMapWithSize(t, len(entries))
+	for key, value := range entries {
+		k := reflect.New(t.Key()).Elem()
+		if err := l.setValue(k, field, key); err != nil {
+			return err
+		}
+		v := reflect.New(t.Elem()).Elem()
+		if err := l.setValue(v, field, value); err != nil {
+			return err
+		}
+		m.SetMapIndex(k, v)
+	}
+	f.Set(m)
+	return nil
+}
+
+func tagOrDefault(field reflect.StructField, key, def string) string {
+	if value := field.Tag.Get(key); value != "" {
+		return value
+	}
+	return def
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type CollectionConfig struct {
+	Origins []string          `env:"TEST_ORIGINS"`
+	Ports   []int             `env:"TEST_PORTS" sep:";"`
+	Flags   []bool            `env:"TEST_FLAGS"`
+	Labels  map[string]string `env:"TEST_LABELS"`
+	Limits  map[string]int    `env:"TEST_LIMITS" sep:";" kvsep:"="`
+}
+
+func (c *CollectionConfig) SetDefaults() {
+	c.Origins = []string{"localhost"}
+}
+
+func TestLoadSlicesAndMaps(t *testing.T) {
+	os.Setenv("TEST_ORIGINS", "a.example.com, b.example.com,c.example.com")
+	os.Setenv("TEST_PORTS", "80;443")
+	os.Setenv("TEST_FLAGS", "true,false")
+	os.Setenv("TEST_LABELS", "team:core,tier:1")
+	os.Setenv("TEST_LIMITS", "cpu=2;memory=512")
+	defer func() {
+		for _, key := range []string{"TEST_ORIGINS", "TEST_PORTS", "TEST_FLAGS", "TEST_LABELS", "TEST_LIMITS"} {
+			os.Unsetenv(key)
+		}
+	}()
+
+	var cfg CollectionConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if expected := []string{"a.example.com", "b.example.com", "c.example.com"}; !reflect.DeepEqual(cfg.Origins, expected) {
+		t.Errorf("expected Origins to be %v, got %v", expected, cfg.Origins)
+	}
+	if expected := []int{80, 443}; !reflect.DeepEqual(cfg.Ports, expected) {
+		t.Errorf("expected Ports to be %v, got %v", expected, cfg.Ports)
+	}
+	if expected := []bool{true, false}; !reflect.DeepEqual(cfg.Flags, expected) {
+		t.Errorf("expected Flags to be %v, got %v", expected, cfg.Flags)
+	}
+	if expected := map[string]string{"team": "core", "tier": "1"}; !reflect.DeepEqual(cfg.Labels, expected) {
+		t.Errorf("expected Labels to be %v, got %v", expected, cfg.Labels)
+	}
+	if expected := map[string]int{"cpu": 2, "memory": 512}; !reflect.DeepEqual(cfg.Limits, expected) {
+		t.Errorf("expected Limits to be %v, got %v", expected, cfg.Limits)
+	}
+}
+
+func TestEmptySliceKeepsDefault(t *testing.T) {
+	os.Setenv("TEST_ORIGINS", "")
+	defer os.Unsetenv("TEST_ORIGINS")
+
+	var cfg CollectionConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if expected := []string{"localhost"}; !reflect.DeepEqual(cfg.Origins, expected) {
+		t.Errorf("expected Origins to be %v (default), got %v", expected, cfg.Origins)
+	}
+}
+
+type NumericTimeConfig struct {
+	Ratio    float64       `env:"TEST_RATIO"`
+	Workers  uint8         `env:"TEST_WORKERS"`
+	Timeout  time.Duration `env:"TEST_TIMEOUT" validate:"min=1s,max=5m"`
+	Deadline time.Time     `env:"TEST_DEADLINE"`
+	Day      time.Time     `env:"TEST_DAY" layout:"2006-01-02"`
+}
+
+func (c *NumericTimeConfig) SetDefaults() {
+	c.Timeout = 30 * time.Second
+}
+
+func TestLoadNumericAndTimeTypes(t *testing.T) {
+	os.Setenv("TEST_RATIO", "0.75")
+	os.Setenv("TEST_WORKERS", "16")
+	os.Setenv("TEST_TIMEOUT", "90s")
+	os.Setenv("TEST_DEADLINE", "2024-05-01T12:00:00Z")
+	os.Setenv("TEST_DAY", "2024-05-02")
+	defer func() {
+		for _, key := range []string{"TEST_RATIO", "TEST_WORKERS", "TEST_TIMEOUT", "TEST_DEADLINE", "TEST_DAY"} {
+			os.Unsetenv(key)
+		}
+	}()
+
+	var cfg NumericTimeConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Ratio != 0.75 {
+		t.Errorf("expected Ratio to be 0.75, got %v", cfg.Ratio)
+	}
+	if cfg.Workers != 16 {
+		t.Errorf("expected Workers to be 16, got %d", cfg.Workers)
+	}
+	if cfg.Timeout != 90*time.Second {
+		t.Errorf("expected Timeout to be 90s, got %v", cfg.Timeout)
+	}
+	if expected := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC); !cfg.Deadline.Equal(expected) {
+		t.Errorf("expected Deadline to be %v, got %v", expected, cfg.Deadline)
+	}
+	if expected := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC); !cfg.Day.Equal(expected) {
+		t.Errorf("expected Day to be %v, got %v", expected, cfg.Day)
+	}
+}
+
+func TestDurationMinMaxValidation(t *testing.T) {
+	tests := []struct {
+		timeout time.Duration
+		errMsg  string
+	}{
+		{500 * time.Millisecond, "TEST_TIMEOUT: field 'Timeout' must be at least 1s"},
+		{10 * time.Minute, "TEST_TIMEOUT: field 'Timeout' must be at most 5m"},
+		{time.Minute, ""},
+	}
+
+	for _, tt := range tests {
+		cfg := NumericTimeConfig{Timeout: tt.timeout}
+		err := ValidateStruct(&cfg)
+		if tt.errMsg == "" {
+			if err != nil {
+				t.Errorf("expected no error for %v, got: %v", tt.timeout, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.errMsg {
+			t.Errorf("expected error '%s' for %v, got '%v'", tt.errMsg, tt.timeout, err)
+		}
+	}
+}
+
+type testLogLevel int
+
+func (l *testLogLevel) Decode(value string) error {
+	switch strings.ToLower(value) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown log level '%s'", value)
+	}
+	return nil
+}
+
+type CustomDecoderConfig struct {
+	Level    testLogLevel `env:"TEST_LEVEL"`
+	SlogLvl  slog.Level   `env:"TEST_SLOG_LEVEL"`
+	IP       net.IP       `env:"TEST_IP"`
+	Addr     netip.Addr   `env:"TEST_ADDR"`
+	Endpoint *url.URL     `env:"TEST_ENDPOINT"`
+	Peers    []netip.Addr `env:"TEST_PEERS"`
+}
+
+func (c *CustomDecoderConfig) SetDefaults() {
+	c.Level = 1
+}
+
+func TestLoadCustomDecoders(t *testing.T) {
+	os.Setenv("TEST_LEVEL", "debug")
+	os.Setenv("TEST_SLOG_LEVEL", "warn")
+	os.Setenv("TEST_IP", "10.0.0.1")
+	os.Setenv("TEST_ADDR", "::1")
+	os.Setenv("TEST_ENDPOINT", "https://api.example.com/v1")
+	os.Setenv("TEST_PEERS", "10.0.0.2,10.0.0.3")
+	defer func() {
+		for _, key := range []string{"TEST_LEVEL", "TEST_SLOG_LEVEL", "TEST_IP", "TEST_ADDR", "TEST_ENDPOINT", "TEST_PEERS"} {
+			os.Unsetenv(key)
+		}
+	}()
+
+	var cfg CustomDecoderConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Level != 0 {
+		t.Errorf("expected Level to be 0, got %d", cfg.Level)
+	}
+	if cfg.SlogLvl != slog.LevelWarn {
+		t.Errorf("expected SlogLvl to be WARN, got %v", cfg.SlogLvl)
+	}
+	if !cfg.IP.Equal(net.ParseIP("10.0.0.1")) {
+		t.Errorf("expected IP to be 10.0.0.1, got %v", cfg.IP)
+	}
+	if cfg.Addr != netip.MustParseAddr("::1") {
+		t.Errorf("expected Addr to be ::1, got %v", cfg.Addr)
+	}
+	if cfg.Endpoint == nil || cfg.Endpoint.Host != "api.example.com" {
+		t.Errorf("expected Endpoint host to be 'api.example.com', got %v", cfg.Endpoint)
+	}
+	if expected := []netip.Addr{netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("10.0.0.3")}; !reflect.DeepEqual(cfg.Peers, expected) {
+		t.Errorf("expected Peers to be %v, got %v", expected, cfg.Peers)
+	}
+}
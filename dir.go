@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DirOption configures a DirSource.
+type DirOption func(*dirSource)
+
+// DirKeyFunc sets the function that turns a file name into a key. By
+// default file names are used as they are; EnvKey maps names such as
+// db-password onto variable names such as DB_PASSWORD.
+func DirKeyFunc(fn func(name string) string) DirOption {
+	return func(s *dirSource) {
+		s.key = fn
+	}
+}
+
+// DirIncludeHidden reads files whose names start with a dot. They are
+// skipped by default, which hides the ..data and timestamped directories a
+// Kubernetes volume mount keeps next to its keys.
+func DirIncludeHidden() DirOption {
+	return func(s *dirSource) {
+		s.hidden = true
+	}
+}
+
+type dirSource struct {
+	path string
+	// env names a variable holding the directory, resolved on Refresh
+	env    string
+	key    func(name string) string
+	hidden bool
+
+	mu     sync.RWMutex
+	dir    string
+	values map[string]string
+	files  map[string]string
+}
+
+// DirSource returns a Source that reads a directory in which every file
+// name is a key and the file's contents its value, with trailing newlines
+// removed. This is the layout of Kubernetes ConfigMap and Secret volumes and
+// of Docker's /run/secrets. The directory is read on every Load; a missing
+// directory provides no values, and subdirectories are ignored.
+func DirSource(path string, opts ...DirOption) Source {
+	return newDirSource(&dirSource{path: path}, opts)
+}
+
+// CredentialsSource returns a DirSource for the directory named by
+// $CREDENTIALS_DIRECTORY, where systemd places the credentials of a service.
+// It provides no values when the variable is unset.
+func CredentialsSource(opts ...DirOption) Source {
+	return newDirSource(&dirSource{env: "CREDENTIALS_DIRECTORY"}, opts)
+}
+
+func newDirSource(s *dirSource, opts []DirOption) *dirSource {
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *dirSource) Name() string {
+	if s.env != "" {
+		return "$" + s.env
+	}
+	return s.path
+}
+
+func (s *dirSource) Lookup(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// file returns the path of the file that provides key.
+func (s *dirSource) file(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return filepath.Join(s.dir, s.files[key])
+}
+
+// watchPaths returns the directory, whose modification time changes when
+// files are added or removed, and the files read from it.
+func (s *dirSource) watchPaths() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.dir == "" {
+		return nil
+	}
+	paths := []string{s.dir}
+	for _, name := range s.files {
+		paths = append(paths, filepath.Join(s.dir, name))
+	}
+	return paths
+}
+
+func (s *dirSource) Refresh() error {
+	dir := s.path
+	if s.env != "" {
+		dir = os.Getenv(s.env)
+	}
+
+	values, files, err := s.read(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	s.mu.Lock()
+	s.dir, s.values, s.files = dir, values, files
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *dirSource) read(dir string) (map[string]string, map[string]string, error) {
+	if dir == "" {
+		return nil, nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	values := map[string]string{}
+	files := map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") && !s.hidden {
+			continue
+		}
+		// keys in Kubernetes volumes are symlinks, so follow them, skipping
+		// any left dangling by an update in progress
+		info, err := os.Stat(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key := name
+		if s.key != nil {
+			key = s.key(name)
+		}
+		if other, exists := files[key]; exists {
+			return nil, nil, fmt.Errorf("files %s and %s both provide %s", other, name, key)
+		}
+		values[key] = strings.TrimRight(string(data), "\r\n")
+		files[key] = name
+	}
+	return values, files, nil
+}
+
+// EnvKey converts a file name into an environment variable name by upper
+// casing it and replacing every character other than a letter or digit with
+// an underscore, e.g. db-password.txt becomes DB_PASSWORD_TXT.
+func EnvKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type DirConfig struct {
+	DBPassword string `env:"DB_PASSWORD"`
+	APIKey     string `env:"API_KEY"`
+	LogLevel   string `env:"LOG_LEVEL"`
+}
+
+func (c *DirConfig) SetDefaults() {
+	c.LogLevel = "info"
+}
+
+// writeKubernetesVolume lays out files the way the kubelet mounts a
+// ConfigMap or Secret: keys are symlinks into ..data, itself a symlink to a
+// timestamped directory.
+func writeKubernetesVolume(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	data := filepath.Join(dir, "..2026_10_17_10_00_00.000000000")
+	if err := os.Mkdir(data, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", data, err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(data, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	if err := os.Symlink(filepath.Base(data), filepath.Join(dir, "..data")); err != nil {
+		t.Fatalf("Failed to create ..data: %v", err)
+	}
+	for name := range files {
+		if err := os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)); err != nil {
+			t.Fatalf("Failed to link %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestDirSourceKubernetesVolume(t *testing.T) {
+	dir := writeKubernetesVolume(t, map[string]string{
+		"db-password": "s3cret\n",
+		"log.level":   "debug",
+	})
+
+	loader := New(WithSources(DirSource(dir, DirKeyFunc(EnvKey))))
+
+	var cfg DirConfig
+	provenance, err := loader.LoadExplain(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.DBPassword != "s3cret" {
+		t.Errorf("expected DBPassword to be 's3cret', got '%s'", cfg.DBPassword)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("expected LogLevel to be 'debug', got '%s'", cfg.LogLevel)
+	}
+
+	origin, _ := provenance.Field("DBPassword")
+	if origin.Source != "dir" || origin.File != filepath.Join(dir, "db-password") {
+		t.Errorf("expected DBPassword to come from %s, got %s", filepath.Join(dir, "db-password"), origin)
+	}
+}
+
+func TestDirSourceSkipsHiddenFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"API_KEY": "key", ".LOG_LEVEL": "debug"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "DB_PASSWORD"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	src := DirSource(dir)
+	if err := src.(Refresher).Refresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value, _ := src.Lookup("API_KEY"); value != "key" {
+		t.Errorf("expected API_KEY to be 'key', got '%s'", value)
+	}
+	if _, ok := src.Lookup(".LOG_LEVEL"); ok {
+		t.Error("expected hidden files to be skipped")
+	}
+	if _, ok := src.Lookup("DB_PASSWORD"); ok {
+		t.Error("expected directories to be skipped")
+	}
+
+	src = DirSource(dir, DirIncludeHidden())
+	if err := src.(Refresher).Refresh(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value, _ := src.Lookup(".LOG_LEVEL"); value != "debug" {
+		t.Errorf("expected .LOG_LEVEL to be 'debug', got '%s'", value)
+	}
+}
+
+func TestCredentialsSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "api_key"), []byte("from-systemd"), 0600); err != nil {
+		t.Fatalf("Failed to create credential: %v", err)
+	}
+
+	os.Setenv("CREDENTIALS_DIRECTORY", dir)
+	defer os.Unsetenv("CREDENTIALS_DIRECTORY")
+
+	var cfg DirConfig
+	err := New(WithoutDotenv(), WithLookup(mapLookup(nil)), WithFileSources(CredentialsSource(DirKeyFunc(EnvKey)))).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.APIKey != "from-systemd" {
+		t.Errorf("expected APIKey to be 'from-systemd', got '%s'", cfg.APIKey)
+	}
+
+	os.Unsetenv("CREDENTIALS_DIRECTORY")
+	src := CredentialsSource()
+	if err := src.(Refresher).Refresh(); err != nil {
+		t.Fatalf("expected no error without CREDENTIALS_DIRECTORY, got %v", err)
+	}
+	if _, ok := src.Lookup("api_key"); ok {
+		t.Error("expected no values without CREDENTIALS_DIRECTORY")
+	}
+}
+
+func TestEnvKey(t *testing.T) {
+	tests := map[string]string{
+		"db-password": "DB_PASSWORD",
+		"log.level":   "LOG_LEVEL",
+		"API_KEY":     "API_KEY",
+	}
+	for name, want := range tests {
+		if got := EnvKey(name); got != want {
+			t.Errorf("expected EnvKey(%q) to be '%s', got '%s'", name, want, got)
+		}
+	}
+}
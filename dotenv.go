@@ -0,0 +1,230 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// dotenvEntry is a single assignment in a dotenv file.
+type dotenvEntry struct {
+	key   string
+	value string
+	line  int
+	// quote is the quote the value was enclosed in, if any
+	quote byte
+}
+
+// dotenvParser lexes dotenv files the way docker compose does:
+//
+//   - KEY=value and export KEY=value, with blank lines and # comments
+//   - unquoted values end at the end of the line or at a # preceded by
+//     whitespace, and surrounding whitespace is trimmed
+//   - double-quoted values may span lines and support the escapes \n, \r,
+//     \t, \", \\ and \$
+//   - single-quoted values may span lines and are taken literally
+//
+// Malformed assignments and repeated keys are skipped and recorded in errs.
+type dotenvParser struct {
+	data string
+	pos  int
+	line int
+
+	entries []dotenvEntry
+	errs    []*DotenvSyntaxError
+	// seen holds the line each key was first set on
+	seen map[string]int
+}
+
+// parseDotenv returns the assignments in data and the syntax errors found.
+// Malformed lines and repeated keys are left out of the assignments.
+func parseDotenv(data string) ([]dotenvEntry, []*DotenvSyntaxError) {
+	p := &dotenvParser{data: strings.TrimPrefix(data, "\ufeff"), line: 1, seen: map[string]int{}}
+	for p.pos < len(p.data) {
+		p.skipBlank()
+		switch {
+		case p.eol():
+			p.nextLine()
+		case p.data[p.pos] == '#':
+			p.skipLine()
+		default:
+			p.parseAssignment()
+		}
+	}
+	return p.entries, p.errs
+}
+
+func (p *dotenvParser) parseAssignment() {
+	line := p.line
+	if rest := p.data[p.pos:]; strings.HasPrefix(rest, "export") && len(rest) > 6 && (rest[6] == ' ' || rest[6] == '\t') {
+		p.pos += len("export")
+		p.skipBlank()
+	}
+
+	keyStart := p.pos
+	for !p.eol() && !strings.ContainsRune("= \t", rune(p.data[p.pos])) {
+		p.pos++
+	}
+	key := p.data[keyStart:p.pos]
+	if key == "" {
+		p.fail(keyStart, "missing variable name")
+		return
+	}
+	for i := 0; i < len(key); i++ {
+		if !isKeyChar(key[i]) || i == 0 && !isNameStart(key[i]) {
+			r, _ := utf8.DecodeRuneInString(key[i:])
+			p.fail(keyStart+i, fmt.Sprintf("invalid character %q in variable name", r))
+			return
+		}
+	}
+
+	p.skipBlank()
+	if p.eol() || p.data[p.pos] != '=' {
+		p.fail(p.pos, fmt.Sprintf("missing '=' after %s", key))
+		return
+	}
+	p.pos++
+	p.skipBlank()
+
+	entry := dotenvEntry{key: key, line: line}
+	if first, ok := p.seen[key]; ok {
+		p.errorAt(keyStart, fmt.Sprintf("duplicate key %s, first set on line %d", key, first))
+	}
+	if !p.eol() && (p.data[p.pos] == '"' || p.data[p.pos] == '\'') {
+		quoteStart := p.pos
+		value, ok := p.parseQuoted()
+		if !ok {
+			// keep the rest of the line as it is, like an unquoted value
+			p.pos, p.line = quoteStart, line
+			p.errorAt(quoteStart, "unterminated quoted value")
+			entry.value = p.parseUnquoted()
+			p.add(entry)
+			return
+		}
+		entry.value, entry.quote = value, p.data[quoteStart]
+		p.add(entry)
+
+		p.skipBlank()
+		if !p.eol() && p.data[p.pos] != '#' {
+			r, _ := utf8.DecodeRuneInString(p.data[p.pos:])
+			p.errorAt(p.pos, fmt.Sprintf("unexpected %q after quoted value", r))
+		}
+		p.skipLine()
+		return
+	}
+
+	entry.value = p.parseUnquoted()
+	p.add(entry)
+}
+
+// add records entry unless its key has been set already.
+func (p *dotenvParser) add(entry dotenvEntry) {
+	if _, ok := p.seen[entry.key]; ok {
+		return
+	}
+	p.seen[entry.key] = entry.line
+	p.entries = append(p.entries, entry)
+}
+
+// parseUnquoted returns the rest of the line up to an inline comment.
+func (p *dotenvParser) parseUnquoted() string {
+	start := p.pos
+	end := start
+	for ; end < len(p.data) && p.data[end] != '\n'; end++ {
+		if p.data[end] == '#' && end > 0 && (p.data[end-1] == ' ' || p.data[end-1] == '\t') {
+			break
+		}
+	}
+	value := strings.TrimRight(p.data[start:end], " \t\r")
+	p.pos = end
+	p.skipLine()
+	return value
+}
+
+// parseQuoted returns the value enclosed in the quote at pos, unescaping
+// double-quoted values. An escaped $ is returned as $$ so that expand keeps
+// it literal. It reports false if the quote is not closed.
+func (p *dotenvParser) parseQuoted() (string, bool) {
+	quote := p.data[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), true
+		case c == '\n':
+			b.WriteByte(c)
+			p.nextLine()
+			continue
+		case c == '\r' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '\n':
+			// CRLF line endings inside a value become LF
+		case c == '\\' && quote == '"' && p.pos+1 < len(p.data):
+			p.pos++
+			switch e := p.data[p.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\':
+				b.WriteByte(e)
+			case '$':
+				b.WriteString("$$")
+			default:
+				b.WriteByte('\\')
+				continue
+			}
+		default:
+			b.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", false
+}
+
+// fail records a syntax error at offset and skips the rest of the line.
+func (p *dotenvParser) fail(offset int, reason string) {
+	p.errorAt(offset, reason)
+	p.skipLine()
+}
+
+func (p *dotenvParser) errorAt(offset int, reason string) {
+	line, col := position(p.data, offset)
+	p.errs = append(p.errs, &DotenvSyntaxError{Line: line, Column: col, Reason: reason})
+}
+
+func (p *dotenvParser) skipBlank() {
+	for p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t' || p.data[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) eol() bool {
+	return p.pos >= len(p.data) || p.data[p.pos] == '\n'
+}
+
+// skipLine moves to the start of the next line.
+func (p *dotenvParser) skipLine() {
+	for !p.eol() {
+		p.pos++
+	}
+	if p.pos < len(p.data) {
+		p.nextLine()
+	}
+}
+
+// nextLine moves past the newline at pos.
+func (p *dotenvParser) nextLine() {
+	p.pos++
+	p.line++
+}
+
+// isKeyChar reports whether c may appear in a variable name. Besides the
+// characters of shell names, docker compose allows dots and dashes.
+func isKeyChar(c byte) bool {
+	return isNameChar(c) || c == '.' || c == '-'
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type StrictDotenvConfig struct {
+	Host string `env:"STRICT_HOST"`
+	Port int    `env:"STRICT_PORT"`
+}
+
+func (c *StrictDotenvConfig) SetDefaults() {}
+
+func TestDotenvSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		column  int
+		reason  string
+	}{
+		{"missing equals", "STRICT_HOST=a\nSTRICT_PORT 80\n", 2, 13, "missing '=' after STRICT_PORT"},
+		{"missing name", "  =value\n", 1, 3, "missing variable name"},
+		{"invalid name", "STRICT$HOST=a\n", 1, 7, "invalid character '$' in variable name"},
+		{"invalid first character", "1HOST=a\n", 1, 1, "invalid character '1' in variable name"},
+		{"unterminated quote", "STRICT_HOST=a\nSTRICT_PORT=\"80\n", 2, 13, "unterminated quoted value"},
+		{"text after quotes", "STRICT_HOST=\"a\" b\n", 1, 17, "unexpected 'b' after quoted value"},
+		{"duplicate key", "STRICT_HOST=a\n\nexport STRICT_HOST=b\n", 3, 8, "duplicate key STRICT_HOST, first set on line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.env")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create %s: %v", path, err)
+			}
+
+			var cfg StrictDotenvConfig
+			err := New(WithEnvFiles(path), WithStrictDotenv(), WithoutEnvMutation()).Load(&cfg)
+
+			var syntaxErr *DotenvSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected *DotenvSyntaxError, got %T: %v", err, err)
+			}
+			if syntaxErr.File != path {
+				t.Errorf("expected File to be '%s', got '%s'", path, syntaxErr.File)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("expected error at %d:%d, got %d:%d", tt.line, tt.column, syntaxErr.Line, syntaxErr.Column)
+			}
+			if syntaxErr.Reason != tt.reason {
+				t.Errorf("expected Reason to be '%s', got '%s'", tt.reason, syntaxErr.Reason)
+			}
+		})
+	}
+}
+
+func TestDotenvWarnings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	content := "STRICT_HOST=first\nMALFORMED\nSTRICT_HOST=second\nSTRICT_PORT=8080\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+
+	var warnings []string
+	var cfg StrictDotenvConfig
+	err := New(
+		WithEnvFiles(path),
+		WithoutEnvMutation(),
+		WithDotenvWarnings(func(err *DotenvSyntaxError) {
+			warnings = append(warnings, err.Error())
+		}),
+	).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected lenient parsing to succeed, got error: %v", err)
+	}
+
+	if cfg.Host != "first" {
+		t.Errorf("expected Host to be 'first', got '%s'", cfg.Host)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("expected Port to be 8080, got %d", cfg.Port)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", len(warnings), warnings)
+	}
+	if !strings.HasPrefix(warnings[0], path+":2:10: missing '='") {
+		t.Errorf("unexpected first warning: %s", warnings[0])
+	}
+	if !strings.HasPrefix(warnings[1], path+":3:1: duplicate key STRICT_HOST") {
+		t.Errorf("unexpected second warning: %s", warnings[1])
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{"unquoted", "KEY=value", map[string]string{"KEY": "value"}},
+		{"spaces around equals", "KEY = value  ", map[string]string{"KEY": "value"}},
+		{"export prefix", "export KEY=value\nexport\tOTHER=x", map[string]string{"KEY": "value", "OTHER": "x"}},
+		{"export as key", "export=1", map[string]string{"export": "1"}},
+		{"inline comment", "KEY=value # comment", map[string]string{"KEY": "value"}},
+		{"hash without space", "KEY=abc#def", map[string]string{"KEY": "abc#def"}},
+		{"comment only value", "KEY= # comment", map[string]string{"KEY": ""}},
+		{"empty value", "KEY=", map[string]string{"KEY": ""}},
+		{"double quoted", `KEY="quoted # not a comment"`, map[string]string{"KEY": "quoted # not a comment"}},
+		{"comment after quotes", `KEY="value" # comment`, map[string]string{"KEY": "value"}},
+		{"single quoted", `KEY='it\n stays'`, map[string]string{"KEY": `it\n stays`}},
+		{"escapes", `KEY="a\nb\tc\"d\\e\$f\q"`, map[string]string{"KEY": "a\nb\tc\"d\\e$$f\\q"}},
+		{"multi-line double quoted", "KEY=\"line1\nline2\"\nNEXT=1", map[string]string{"KEY": "line1\nline2", "NEXT": "1"}},
+		{"multi-line single quoted", "KEY='line1\nline2'", map[string]string{"KEY": "line1\nline2"}},
+		{"crlf", "A=1\r\nB=\"x\r\ny\"\r\n", map[string]string{"A": "1", "B": "x\ny"}},
+		{"byte order mark", "\ufeffKEY=value", map[string]string{"KEY": "value"}},
+		{"dots and dashes", "my.key-name=1", map[string]string{"my.key-name": "1"}},
+		{"unterminated quote", "KEY=\"abc\nNEXT=1", map[string]string{"KEY": `"abc`, "NEXT": "1"}},
+		{"malformed lines skipped", "NO_EQUALS\n=VALUE\n1KEY=x\nKEY=ok", map[string]string{"KEY": "ok"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, _ := parseDotenv(tt.input)
+			got := map[string]string{}
+			for _, entry := range entries {
+				got[entry.key] = entry.value
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("expected %d values, got %d: %q", len(tt.want), len(got), got)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("expected %s to be %q, got %q", key, want, got[key])
+				}
+			}
+		})
+	}
+}
+
+func TestParseDotenvLineNumbers(t *testing.T) {
+	entries, _ := parseDotenv("# comment\nA=1\nB=\"multi\nline\"\n\nC=3\n")
+	want := map[string]int{"A": 2, "B": 3, "C": 6}
+	for _, entry := range entries {
+		if entry.line != want[entry.key] {
+			t.Errorf("expected %s on line %d, got %d", entry.key, want[entry.key], entry.line)
+		}
+	}
+}
+
+func TestReadEnvFileFullGrammar(t *testing.T) {
+	key := "-----BEGIN KEY-----\nabc\n-----END KEY-----"
+	long := strings.Repeat("x", 100*1024)
+	content := "export PEM=\"" + strings.ReplaceAll(key, "\n", "\\n") + "\"\n" +
+		"RAW_PEM=\"" + key + "\"\n" +
+		"LONG=" + long + "\n" +
+		"HOST=localhost # inline comment\n" +
+		"PRICE=\"\\$5\"\n"
+
+	path := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+
+	values, lines, err := readEnvFile(path, dotenvConfig{strict: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if values["PEM"] != key {
+		t.Errorf("expected PEM to be %q, got %q", key, values["PEM"])
+	}
+	if values["RAW_PEM"] != key {
+		t.Errorf("expected RAW_PEM to be %q, got %q", key, values["RAW_PEM"])
+	}
+	if values["LONG"] != long {
+		t.Errorf("expected LONG to have %d characters, got %d", len(long), len(values["LONG"]))
+	}
+	if values["HOST"] != "localhost" {
+		t.Errorf("expected HOST to be 'localhost', got '%s'", values["HOST"])
+	}
+	if values["PRICE"] != "$5" {
+		t.Errorf("expected PRICE to be '$5', got '%s'", values["PRICE"])
+	}
+	if lines["LONG"] != 5 {
+		t.Errorf("expected LONG on line 5, got %d", lines["LONG"])
+	}
+}
+
+// dotenvCorpus describes how dotenv files are read, as users of docker
+// compose and other dotenv tools expect. Values are after interpolation;
+// warnings counts the lines skipped as malformed.
+var dotenvCorpus = []struct {
+	name     string
+	input    string
+	want     map[string]string
+	warnings int
+}{
+	// line endings and encoding
+	{"lf", "A=1\nB=2\n", map[string]string{"A": "1", "B": "2"}, 0},
+	{"crlf", "A=1\r\nB=2\r\n", map[string]string{"A": "1", "B": "2"}, 0},
+	{"crlf quoted", "A=\"1\"\r\nB='2'\r\n", map[string]string{"A": "1", "B": "2"}, 0},
+	{"crlf multi-line", "A=\"x\r\ny\"\r\n", map[string]string{"A": "x\ny"}, 0},
+	{"no trailing newline", "A=1", map[string]string{"A": "1"}, 0},
+	{"bom", "\ufeffA=1\n", map[string]string{"A": "1"}, 0},
+	{"bom before comment", "\ufeff# comment\nA=1\n", map[string]string{"A": "1"}, 0},
+	{"empty file", "", map[string]string{}, 0},
+	{"only comments", "# a\n  # b\n\n", map[string]string{}, 0},
+
+	// empty values
+	{"empty unquoted", "A=\n", map[string]string{"A": ""}, 0},
+	{"empty double quoted", "A=\"\"\n", map[string]string{"A": ""}, 0},
+	{"empty single quoted", "A=''\n", map[string]string{"A": ""}, 0},
+	{"empty with spaces", "A=   \n", map[string]string{"A": ""}, 0},
+	{"empty with comment", "A= # nothing\n", map[string]string{"A": ""}, 0},
+
+	// = inside values
+	{"equals unquoted", "A=b=c\n", map[string]string{"A": "b=c"}, 0},
+	{"equals quoted", "A=\"b=c==\"\n", map[string]string{"A": "b=c=="}, 0},
+	{"base64 padding", "A=dGVzdA==\n", map[string]string{"A": "dGVzdA=="}, 0},
+	{"url query", "A=https://x.test/?a=1&b=2\n", map[string]string{"A": "https://x.test/?a=1&b=2"}, 0},
+
+	// quotes inside values
+	{"apostrophe unquoted", "A=it's\n", map[string]string{"A": "it's"}, 0},
+	{"double quote unquoted", "A=say \"hi\"\n", map[string]string{"A": "say \"hi\""}, 0},
+	{"escaped double quote", "A=\"say \\\"hi\\\"\"\n", map[string]string{"A": "say \"hi\""}, 0},
+	{"double in single", "A='say \"hi\"'\n", map[string]string{"A": "say \"hi\""}, 0},
+	{"single in double", "A=\"it's\"\n", map[string]string{"A": "it's"}, 0},
+	{"quote in the middle", "A=a\"b\n", map[string]string{"A": "a\"b"}, 0},
+	{"hash in quotes", "A=\"#1\" # comment\n", map[string]string{"A": "#1"}, 0},
+
+	// whitespace around keys and values
+	{"spaces around key", "  A  =1\n", map[string]string{"A": "1"}, 0},
+	{"tabs around key", "\tA\t=\t1\t\n", map[string]string{"A": "1"}, 0},
+	{"spaces around value", "A=  1  \n", map[string]string{"A": "1"}, 0},
+	{"inner spaces kept", "A=a  b\n", map[string]string{"A": "a  b"}, 0},
+	{"quoted spaces kept", "A=\"  a  \"\n", map[string]string{"A": "  a  "}, 0},
+	{"export", "export A=1\n", map[string]string{"A": "1"}, 0},
+	{"export with spaces", "  export   A = 1\n", map[string]string{"A": "1"}, 0},
+
+	// unicode
+	{"unicode unquoted", "A=héllo wörld\n", map[string]string{"A": "héllo wörld"}, 0},
+	{"unicode quoted", "A=\"日本語\"\n", map[string]string{"A": "日本語"}, 0},
+	{"emoji", "A='🚀 launch'\n", map[string]string{"A": "🚀 launch"}, 0},
+	{"unicode key", "ÄPFEL=1\nA=2\n", map[string]string{"A": "2"}, 1},
+
+	// escapes and interpolation
+	{"escapes", "A=\"a\\tb\\nc\\\\d\"\n", map[string]string{"A": "a\tb\nc\\d"}, 0},
+	{"unknown escape kept", "A=\"C:\\path\"\n", map[string]string{"A": "C:\\path"}, 0},
+	{"backslash unquoted", "A=C:\\path\\n\n", map[string]string{"A": "C:\\path\\n"}, 0},
+	{"escaped dollar", "A=\"\\$HOME\"\n", map[string]string{"A": "$HOME"}, 0},
+	{"reference", "A=1\nB=${A}2\n", map[string]string{"A": "1", "B": "12"}, 0},
+	{"reference single quoted", "A=1\nB='${A}'\n", map[string]string{"A": "1", "B": "${A}"}, 0},
+	{"forward reference", "B=${A:-none}\nA=1\n", map[string]string{"A": "1", "B": "none"}, 0},
+
+	// malformed lines
+	{"no equals", "A\nB=1\n", map[string]string{"B": "1"}, 1},
+	{"no key", "=1\nB=1\n", map[string]string{"B": "1"}, 1},
+	{"space in key", "A B=1\n", map[string]string{}, 1},
+	{"duplicate", "A=1\nA=2\n", map[string]string{"A": "1"}, 1},
+	{"unterminated", "A=\"1\nB=2\n", map[string]string{"A": "\"1", "B": "2"}, 1},
+}
+
+func TestDotenvCompliance(t *testing.T) {
+	for _, tt := range dotenvCorpus {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := 0
+			values, _, err := decodeEnvFile("test.env", tt.input, dotenvConfig{warn: func(*DotenvSyntaxError) {
+				warnings++
+			}})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if warnings != tt.warnings {
+				t.Errorf("expected %d warnings, got %d", tt.warnings, warnings)
+			}
+			if len(values) != len(tt.want) {
+				t.Errorf("expected %d values, got %d: %q", len(tt.want), len(values), values)
+			}
+			for key, want := range tt.want {
+				if got, ok := values[key]; !ok || got != want {
+					t.Errorf("expected %s to be %q, got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+// formatDotenv writes values as a dotenv file that decodes back to them.
+func formatDotenv(values map[string]string) string {
+	var b strings.Builder
+	for key, value := range values {
+		value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\r", `\r`).Replace(value)
+		b.WriteString(key + "=\"" + value + "\"\n")
+	}
+	return b.String()
+}
+
+func FuzzParseDotenv(f *testing.F) {
+	for _, tt := range dotenvCorpus {
+		f.Add(tt.input)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		entries, errs := parseDotenv(input)
+		lines := strings.Count(input, "\n") + 1
+		for _, entry := range entries {
+			if entry.key == "" {
+				t.Errorf("parsed an empty key from %q", input)
+			}
+			if entry.line < 1 || entry.line > lines {
+				t.Errorf("line %d of %s is out of range in %q", entry.line, entry.key, input)
+			}
+		}
+		for _, err := range errs {
+			if err.Line < 1 || err.Line > lines || err.Column < 1 {
+				t.Errorf("error position %d:%d is out of range in %q", err.Line, err.Column, input)
+			}
+		}
+	})
+}
+
+func FuzzDotenvRoundTrip(f *testing.F) {
+	f.Add("KEY", "value")
+	f.Add("A", "")
+	f.Add("PEM", "-----BEGIN-----\nabc\n-----END-----\n")
+	f.Add("Q", `say "hi" \n $HOME ${X:-y} 'single'`)
+	f.Add("U", "日本語 🚀\r\n\t")
+	f.Add("my.key-1", "# not a comment")
+	f.Fuzz(func(t *testing.T, key, value string) {
+		if key == "" || !isNameStart(key[0]) || strings.IndexFunc(key, func(r rune) bool { return r > 0x7f || !isKeyChar(byte(r)) }) >= 0 {
+			t.Skip()
+		}
+
+		values, _, err := decodeEnvFile("fuzz.env", formatDotenv(map[string]string{key: value}), dotenvConfig{strict: true})
+		if err != nil {
+			t.Fatalf("failed to parse formatted %q=%q: %v", key, value, err)
+		}
+		if got, ok := values[key]; !ok || got != value {
+			t.Errorf("expected %s to round-trip as %q, got %q", key, value, got)
+		}
+	})
+}
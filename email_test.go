@@ -32,7 +32,7 @@ func TestEmailValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid email, got nil")
 	}
-	expectedErr := "validation error: field 'Email' must be a valid email address"
+	expectedErr := "validation error: TEST_EMAIL: field 'Email' must be a valid email address"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
@@ -184,3 +184,45 @@ MALFORMED_LINE_NO_EQUALS
 		t.Errorf("Expected VAR5 to be empty, got '%s'", value)
 	}
 }
+
+func TestWithoutEnvMutation(t *testing.T) {
+	envVars := []string{"APP_NAME", "PORT", "DEBUG", "DATABASE_URL"}
+	for _, env := range envVars {
+		os.Unsetenv(env)
+	}
+
+	envContent := `APP_NAME=EnvFileApp
+PORT=3000
+DATABASE_URL=env_file_db`
+
+	err := os.WriteFile(".env", []byte(envContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create .env file: %v", err)
+	}
+	defer os.Remove(".env")
+
+	os.Setenv("APP_NAME", "EnvironmentApp")
+	defer os.Unsetenv("APP_NAME")
+
+	var cfg EnvFileConfig
+	err = Load(&cfg, WithoutEnvMutation())
+	if err != nil {
+		t.Fatalf("Expected successful load, got error: %v", err)
+	}
+
+	if cfg.AppName != "EnvironmentApp" {
+		t.Errorf("Expected AppName to be 'EnvironmentApp' (from env), got '%s'", cfg.AppName)
+	}
+	if cfg.Port != 3000 {
+		t.Errorf("Expected Port to be 3000 (from .env file), got %d", cfg.Port)
+	}
+	if cfg.DatabaseURL != "env_file_db" {
+		t.Errorf("Expected DatabaseURL to be 'env_file_db' (from .env file), got '%s'", cfg.DatabaseURL)
+	}
+
+	for _, env := range []string{"PORT", "DATABASE_URL"} {
+		if _, exists := os.LookupEnv(env); exists {
+			t.Errorf("Expected %s not to be set in the process environment", env)
+		}
+	}
+}
@@ -32,7 +32,7 @@ func TestEqValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid EqField, got nil")
 	}
-	expectedErr := "validation error: field 'EqField' must be equal to 'hello'"
+	expectedErr := "validation error: TEST_EQ: field 'EqField' must be equal to 'hello'"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
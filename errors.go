@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is returned by Load when a value cannot be decoded into the
+// field it is bound to.
+type ParseError struct {
+	Key   string
+	Field string
+	Value string
+	Type  reflect.Type
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid value '%s' for %s (field '%s', type %s): %v", e.Value, e.Key, e.Field, e.Type, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError describes a single failed validation rule.
+type FieldError struct {
+	Field   string
+	Key     string
+	Rule    string
+	Param   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Key == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// ValidationErrors holds every failed validation rule of a struct.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// SyntaxError reports a malformed config file.
+type SyntaxError struct {
+	File   string
+	Line   int
+	Column int
+	Reason string
+}
+
+// DotenvSyntaxError reports a malformed line in a dotenv file, such as a
+// missing '=', an invalid variable name, an unterminated quote or a
+// duplicate key.
+type DotenvSyntaxError = SyntaxError
+
+func (e *SyntaxError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Reason)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Reason)
+}
+
+// position converts a byte offset in data to a 1-based line and column.
+func position(data string, offset int) (int, int) {
+	data = data[:min(offset, len(data))]
+	line := strings.Count(data, "\n") + 1
+	column := utf8.RuneCountInString(data[strings.LastIndexByte(data, '\n')+1:]) + 1
+	return line, column
+}
+
+var (
+	errUnterminatedQuote = errors.New("unterminated quoted string")
+	errInvalidEscape     = errors.New("invalid escape sequence")
+)
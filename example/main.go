@@ -12,8 +12,8 @@ type AppConfig struct {
 	AppName     string `env:"APP_NAME" validate:"required,min=10"`
 	Port        int    `env:"PORT" validate:"required,max=65535"`
 	Debug       bool   `env:"DEBUG"`
-	DatabaseURL string `env:"DATABASE_URL" validate:"required,url"`
-	APIKey      string `env:"API_KEY" validate:"required,min=10"`
+	DatabaseURL string `env:"DATABASE_URL" validate:"required,url" secret:"true"`
+	APIKey      string `env:"API_KEY,secret" validate:"required,min=10"`
 	SMTPHost    string `env:"SMTP_HOST"`
 	SMTPPort    int    `env:"SMTP_PORT" validate:"min=1,max=65535"`
 }
@@ -39,15 +39,9 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// secrets such as the API key are masked; never print them directly
 	fmt.Printf("Loaded configuration:\n")
-	fmt.Printf("  App Name:     %s\n", cfg.AppName)
-	fmt.Printf("  Port:         %d\n", cfg.Port)
-	fmt.Printf("  Debug:        %v\n", cfg.Debug)
-	fmt.Printf("  Database URL: %s\n", cfg.DatabaseURL)
-	fmt.Printf("  API Key:      %s\n", cfg.APIKey)
-	fmt.Printf("  SMTP Host:    %s\n", cfg.SMTPHost)
-	fmt.Printf("  SMTP Port:    %d\n", cfg.SMTPPort)
-
+	fmt.Print(config.Dump(&cfg))
 	fmt.Println()
 	fmt.Println("Try the following to see the priority in action:")
 	fmt.Println("1. Run as-is (uses .env file and defaults)")
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expand replaces references to variables in s with their values, as in
+// docker compose: $NAME and ${NAME}, ${NAME:-default} and ${NAME-default}
+// when NAME is unset or empty (or only unset, without the colon), and
+// ${NAME:?message} and ${NAME?message} to fail in those cases. Defaults and
+// messages may contain references themselves, and $$ is a literal $.
+func expand(s string, lookup func(name string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch next := s[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference '%s'", s[i:])
+			}
+			value, err := expandBraced(s[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i = end
+		case isNameStart(next):
+			end := i + 2
+			for end < len(s) && isNameChar(s[end]) {
+				end++
+			}
+			value, _ := lookup(s[i+1 : end])
+			b.WriteString(value)
+			i = end - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// expandValue expands references in a string value found for a field with
+// the expand option, resolving them against sources. Dotenv values are
+// expanded as the file is read instead.
+func expandValue(value any, sources []Source) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	return expand(s, func(name string) (string, bool) {
+		found, _, ok := lookupSources(sources, name, []string{name})
+		str, isString := found.(string)
+		return str, ok && isString
+	})
+}
+
+// expandBraced expands the contents of a ${...} reference.
+func expandBraced(ref string, lookup func(name string) (string, bool)) (string, error) {
+	end := 0
+	for end < len(ref) && isNameChar(ref[end]) {
+		end++
+	}
+	name, rest := ref[:end], ref[end:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid variable reference '${%s}'", ref)
+	}
+
+	value, ok := lookup(name)
+	if rest == "" {
+		return value, nil
+	}
+
+	// with a colon, an empty value counts as unset
+	unset := !ok
+	if strings.HasPrefix(rest, ":") {
+		unset = value == ""
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return "", fmt.Errorf("invalid variable reference '${%s}'", ref)
+	}
+
+	op, arg := rest[0], rest[1:]
+	switch op {
+	case '-':
+		if unset {
+			return expand(arg, lookup)
+		}
+		return value, nil
+	case '?':
+		if unset {
+			msg, err := expand(arg, lookup)
+			if err != nil {
+				return "", err
+			}
+			if msg == "" {
+				msg = "is required"
+			}
+			return "", fmt.Errorf("%s: %s", name, msg)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid variable reference '${%s}'", ref)
+	}
+}
+
+// closingBrace returns the index of the brace that closes a reference whose
+// contents start at start, allowing for nested references, or -1.
+func closingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || '0' <= c && c <= '9'
+}
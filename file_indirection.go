@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileSuffix names the variable holding the path of a file to read a value
+// from, e.g. API_KEY_FILE for API_KEY.
+const fileSuffix = "_FILE"
+
+// lookupFile finds the _FILE variable of fi in sources and returns the
+// contents of the file it names, without trailing newlines. The origin names
+// the variable and the file.
+func lookupFile(sources []Source, fi fieldInfo) (string, Origin, bool, error) {
+	fileField := fi
+	fileField.key = fi.key + fileSuffix
+	if n := len(fi.keys); n > 0 {
+		fileField.keys = appendKey(fi.keys[:n-1], fi.keys[n-1]+fileSuffix)
+	}
+
+	found, src, ok := lookupSources(sources, fileField.key, fileField.keys)
+	if !ok {
+		return "", Origin{}, false, nil
+	}
+	path, isString := found.(string)
+	if !isString {
+		return "", Origin{}, false, fmt.Errorf("%s must be a file path, got %T", fileField.key, found)
+	}
+	if path == "" {
+		return "", Origin{}, false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", Origin{}, false, fmt.Errorf("failed to read %s: %w", fileField.key, err)
+	}
+	origin := sourceOrigin(fileField, src)
+	origin.File, origin.Line = path, 0
+	return strings.TrimRight(string(data), "\r\n"), origin, true, nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type FileIndirectionConfig struct {
+	APIKey   string `env:"API_KEY,file"`
+	Password string `env:"PASSWORD,secret,file"`
+	Port     int    `env:"PORT"`
+	Token    string `env:"TOKEN"`
+}
+
+func (c *FileIndirectionConfig) SetDefaults() {
+	c.Port = 8080
+}
+
+func TestFileIndirectionPerField(t *testing.T) {
+	apiKey := writeTestFile(t, "secret", "from-file\n")
+	password := writeTestFile(t, "secret", "p4ss\r\n")
+	port := writeTestFile(t, "secret", "9000\n")
+	token := writeTestFile(t, "secret", "token\n")
+
+	loader := New(WithoutDotenv(), WithLookup(mapLookup(map[string]string{
+		"API_KEY_FILE":  apiKey,
+		"PASSWORD_FILE": password,
+		"PORT_FILE":     port,
+		"TOKEN_FILE":    token,
+	})))
+
+	var cfg FileIndirectionConfig
+	provenance, err := loader.LoadExplain(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.APIKey != "from-file" {
+		t.Errorf("expected APIKey to be 'from-file', got '%s'", cfg.APIKey)
+	}
+	if cfg.Password != "p4ss" {
+		t.Errorf("expected Password to be 'p4ss', got '%s'", cfg.Password)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("expected Port to be 8080 without the file option, got %d", cfg.Port)
+	}
+	if cfg.Token != "" {
+		t.Errorf("expected Token to be empty without the file option, got '%s'", cfg.Token)
+	}
+
+	origin, _ := provenance.Field("APIKey")
+	if origin.Source != "env" || origin.Key != "API_KEY_FILE" || origin.File != apiKey {
+		t.Errorf("expected APIKey to come from env API_KEY_FILE (%s), got %s", apiKey, origin)
+	}
+}
+
+func TestFileIndirectionGlobal(t *testing.T) {
+	port := writeTestFile(t, "secret", "9000\n")
+
+	var cfg FileIndirectionConfig
+	err := New(
+		WithoutDotenv(),
+		WithFileIndirection(),
+		WithLookup(mapLookup(map[string]string{"PORT_FILE": port})),
+	).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.Port != 9000 {
+		t.Errorf("expected Port to be 9000, got %d", cfg.Port)
+	}
+}
+
+func TestVariableTakesPrecedenceOverFile(t *testing.T) {
+	apiKey := writeTestFile(t, "secret", "from-file\n")
+
+	var cfg FileIndirectionConfig
+	err := New(WithoutDotenv(), WithLookup(mapLookup(map[string]string{
+		"API_KEY":      "from-env",
+		"API_KEY_FILE": apiKey,
+	}))).Load(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.APIKey != "from-env" {
+		t.Errorf("expected APIKey to be 'from-env', got '%s'", cfg.APIKey)
+	}
+}
+
+func TestMissingIndirectionFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	var cfg FileIndirectionConfig
+	err := New(WithoutDotenv(), WithLookup(mapLookup(map[string]string{"API_KEY_FILE": missing}))).Load(&cfg)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "API_KEY_FILE") {
+		t.Errorf("expected error to mention API_KEY_FILE, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+)
+
+// flagValue is the flag.Value registered for fields with a flag tag. It only
+// records the raw argument; the field is decoded along with every other
+// source during Load.
+type flagValue struct {
+	def     string
+	value   string
+	set     bool
+	boolean bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	if v.set {
+		return v.value
+	}
+	return v.def
+}
+
+func (v *flagValue) Set(s string) error {
+	v.value = s
+	v.set = true
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.boolean
+}
+
+// parseFlags defines a flag for every field of v with a flag tag, unless the
+// flag set already has one of that name, parses the arguments given to
+// WithFlags if that has not happened yet, and returns the values of the
+// flags set on the command line. Defaults shown by -help are the field
+// values set by SetDefaults, masked for secret fields, and usage text comes
+// from the desc tag.
+func (l *Loader) parseFlags(v reflect.Value) (map[string]string, error) {
+	if l.flagSet == nil {
+		return nil, nil
+	}
+
+	names := map[string]bool{}
+	err := walkFields(v, l.prefix, func(fi fieldInfo) error {
+		if fi.flag == "" || fi.nested {
+			return nil
+		}
+		names[fi.flag] = true
+		if l.flagSet.Lookup(fi.flag) != nil {
+			return nil
+		}
+		def := formatValue(fi.value, fi.field)
+		if fi.secret && def != "" {
+			def = redactedValue
+		}
+		l.flagSet.Var(&flagValue{
+			def:     def,
+			boolean: fi.value.Kind() == reflect.Bool,
+		}, fi.flag, fi.field.Tag.Get("desc"))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.flagSet.Parsed() {
+		if err := l.flagSet.Parse(l.flagArgs); err != nil {
+			return nil, fmt.Errorf("failed to parse flags: %w", err)
+		}
+	}
+
+	values := map[string]string{}
+	l.flagSet.Visit(func(f *flag.Flag) {
+		if names[f.Name] {
+			values[f.Name] = f.Value.String()
+		}
+	})
+	return values, nil
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+type FlagConfig struct {
+	Port    int           `env:"PORT" flag:"port" desc:"port to listen on"`
+	Host    string        `env:"HOST" flag:"host" desc:"host to bind"`
+	Debug   bool          `flag:"debug" desc:"enable debug logging"`
+	Timeout time.Duration `env:"TIMEOUT" flag:"timeout"`
+	Tags    []string      `flag:"tags"`
+	Token   string        `flag:"token" secret:"true" desc:"API token"`
+	DB      struct {
+		Name string `env:"NAME" flag:"db-name"`
+	}
+	Vault struct {
+		Role string `flag:"vault-role" desc:"vault role"`
+	} `secret:"true"`
+}
+
+func (c *FlagConfig) SetDefaults() {
+	c.Port = 8080
+	c.Host = "localhost"
+	c.Timeout = 5 * time.Second
+	c.Tags = []string{"a", "b"}
+	c.Token = "default-secret-token"
+	c.DB.Name = "app"
+	c.Vault.Role = "default-vault-role"
+}
+
+func TestFlagsOverrideSources(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := New(
+		WithoutDotenv(),
+		WithLookup(mapLookup(map[string]string{"PORT": "9000", "HOST": "env-host", "DB_NAME": "env-db"})),
+		WithFlags(fs, []string{"-port", "7000", "-debug", "-db-name", "flag-db", "-tags", "x,y"}),
+	)
+
+	var cfg FlagConfig
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Port != 7000 {
+		t.Errorf("expected Port to be 7000 (from flag), got %d", cfg.Port)
+	}
+	if cfg.Host != "env-host" {
+		t.Errorf("expected Host to be 'env-host', got '%s'", cfg.Host)
+	}
+	if !cfg.Debug {
+		t.Error("expected Debug to be true")
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("expected Timeout to be 5s, got %v", cfg.Timeout)
+	}
+	if strings.Join(cfg.Tags, ",") != "x,y" {
+		t.Errorf("expected Tags to be [x y], got %v", cfg.Tags)
+	}
+	if cfg.DB.Name != "flag-db" {
+		t.Errorf("expected DB.Name to be 'flag-db', got '%s'", cfg.DB.Name)
+	}
+}
+
+func TestFlagUsageShowsDefaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out bytes.Buffer
+	fs.SetOutput(&out)
+
+	var cfg FlagConfig
+	err := New(WithoutDotenv(), WithLookup(mapLookup(nil)), WithFlags(fs, []string{"-help"})).Load(&cfg)
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", err)
+	}
+
+	usage := out.String()
+	for _, want := range []string{
+		"port to listen on (default 8080)",
+		"host to bind (default localhost)",
+		"enable debug logging",
+		"(default 5s)",
+		"(default a,b)",
+		"API token (default [REDACTED])",
+		"vault role (default [REDACTED])",
+	} {
+		if !strings.Contains(usage, want) {
+			t.Errorf("expected usage to contain '%s', got:\n%s", want, usage)
+		}
+	}
+	for _, secret := range []string{"default-secret-token", "default-vault-role"} {
+		if strings.Contains(usage, secret) {
+			t.Errorf("expected the secret default '%s' to be masked, got:\n%s", secret, usage)
+		}
+	}
+}
+
+func TestFlagsAlreadyParsed(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("host", "", "defined by the application")
+	if err := fs.Parse([]string{"-host", "parsed"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	var cfg FlagConfig
+	if err := New(WithoutDotenv(), WithLookup(mapLookup(nil)), WithFlags(fs, nil)).Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.Host != "parsed" {
+		t.Errorf("expected Host to be 'parsed', got '%s'", cfg.Host)
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("expected Port to be 8080, got %d", cfg.Port)
+	}
+}
+
+func TestInvalidFlagValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var cfg FlagConfig
+	err := New(WithoutDotenv(), WithLookup(mapLookup(nil)), WithFlags(fs, []string{"-port", "abc"})).Load(&cfg)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *ParseError, got %T: %v", err, err)
+	}
+	if parseErr.Key != "-port" {
+		t.Errorf("expected Key to be '-port', got '%s'", parseErr.Key)
+	}
+	if parseErr.Field != "Port" {
+		t.Errorf("expected Field to be 'Port', got '%s'", parseErr.Field)
+	}
+}
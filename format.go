@@ -0,0 +1,83 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// isFormattable reports whether values of type t, or pointers to them,
+// format themselves as text.
+func isFormattable(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(stringerType) || pt.Implements(textMarshalerType)
+}
+
+// formatValue renders a field value in the form setField parses, so that it
+// can be shown as a default or written back to a variable.
+func formatValue(v reflect.Value, field reflect.StructField) string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return formatValue(v.Elem(), field)
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(tagOrDefault(field, "layout", time.RFC3339))
+	}
+	// methods such as url.URL's String have pointer receivers
+	iv := v
+	if v.CanAddr() {
+		iv = v.Addr()
+	}
+	if iv.CanInterface() {
+		switch x := iv.Interface().(type) {
+		case fmt.Stringer:
+			return x.String()
+		case encoding.TextMarshaler:
+			if text, err := x.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+	}
+
+	sep := tagOrDefault(field, "sep", ",")
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i), field)
+		}
+		return strings.Join(items, sep)
+	case reflect.Map:
+		kvsep := tagOrDefault(field, "kvsep", ":")
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries = append(entries, formatValue(iter.Key(), field)+kvsep+formatValue(iter.Value(), field))
+		}
+		slices.Sort(entries)
+		return strings.Join(entries, sep)
+	}
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+	return ""
+}
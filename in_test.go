@@ -32,7 +32,7 @@ func TestInValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid InField, got nil")
 	}
-	expectedErr := "validation error: field 'InField' must be one of the following values: a|b|c"
+	expectedErr := "validation error: TEST_IN: field 'InField' must be one of the following values: a|b|c"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
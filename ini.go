@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// INISource returns a Source backed by the INI file at path. Sections map
+// onto nested structs as described for WithFileSources, and dotted section
+// names such as [server.tls] onto deeper levels.
+//
+// Keys and values are separated by = or :, and lines starting with ; or #
+// are comments, as are ; and # preceded by whitespace in unquoted values.
+func INISource(path string) Source {
+	return &fileSource{path: path, parse: parseINI}
+}
+
+func parseINI(data []byte) (map[string]any, error) {
+	root := map[string]any{}
+	section := root
+	doc := strings.TrimPrefix(string(data), "\ufeff")
+	for i, raw := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n") {
+		num := i + 1
+		text := strings.TrimSpace(raw)
+		column := strings.Index(raw, text) + 1
+		if text == "" || text[0] == ';' || text[0] == '#' {
+			continue
+		}
+
+		if text[0] == '[' {
+			end := strings.IndexByte(text, ']')
+			if end < 0 {
+				return nil, &SyntaxError{Line: num, Column: column, Reason: "expected ']' to close section header"}
+			}
+			if rest := strings.TrimSpace(text[end+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return nil, &SyntaxError{Line: num, Column: column + end + 1, Reason: "unexpected content after section header"}
+			}
+			section = root
+			for _, name := range strings.Split(text[1:end], ".") {
+				name = strings.TrimSpace(name)
+				if name == "" {
+					return nil, &SyntaxError{Line: num, Column: column + 1, Reason: "empty section name"}
+				}
+				next, exists := section[name]
+				nested, ok := next.(map[string]any)
+				if exists && !ok {
+					return nil, &SyntaxError{Line: num, Column: column + 1, Reason: "section '" + name + "' is already defined as a key"}
+				}
+				if !exists {
+					nested = map[string]any{}
+					section[name] = nested
+				}
+				section = nested
+			}
+			continue
+		}
+
+		sep := strings.IndexAny(text, "=:")
+		if sep < 0 {
+			return nil, &SyntaxError{Line: num, Column: column, Reason: "expected 'key = value'"}
+		}
+		key := strings.TrimSpace(text[:sep])
+		if key == "" {
+			return nil, &SyntaxError{Line: num, Column: column, Reason: "missing key"}
+		}
+		if _, exists := section[key]; exists {
+			return nil, &SyntaxError{Line: num, Column: column, Reason: "duplicate key '" + key + "'"}
+		}
+		valueColumn := column + sep + 1
+		value, err := parseINIValue(strings.TrimSpace(text[sep+1:]))
+		if err != nil {
+			return nil, &SyntaxError{Line: num, Column: valueColumn, Reason: err.Error()}
+		}
+		section[key] = value
+	}
+	return root, nil
+}
+
+// parseINIValue strips an inline comment and matching surrounding quotes.
+func parseINIValue(text string) (string, error) {
+	if text != "" && (text[0] == '"' || text[0] == '\'') {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", errUnterminatedQuote
+		}
+		value := text[1 : end+1]
+		if rest := strings.TrimSpace(text[end+2:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+			return "", errors.New("unexpected content after quoted value")
+		}
+		return value, nil
+	}
+	for i := 1; i < len(text); i++ {
+		if (text[i] == ';' || text[i] == '#') && (text[i-1] == ' ' || text[i-1] == '\t') {
+			return strings.TrimSpace(text[:i]), nil
+		}
+	}
+	return text, nil
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseINI(t *testing.T) {
+	input := `; global settings
+name = ini-app ; trailing comment
+port: 8080
+quoted = "value ; not a comment"
+empty =
+
+[database]
+host = db.internal
+
+# nested section
+[database.options]
+sslmode = require
+`
+	got, err := parseINI([]byte(input))
+	if err != nil {
+		t.Fatalf("expected valid INI, got error: %v", err)
+	}
+
+	expected := map[string]any{
+		"name":   "ini-app",
+		"port":   "8080",
+		"quoted": "value ; not a comment",
+		"empty":  "",
+		"database": map[string]any{
+			"host":    "db.internal",
+			"options": map[string]any{"sslmode": "require"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %#v, got %#v", expected, got)
+	}
+}
+
+func TestParseINIErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		input  string
+		line   int
+		column int
+	}{
+		{"missing separator", "name = a\njust text\n", 2, 1},
+		{"duplicate key", "[db]\nhost = a\n  host = b\n", 3, 3},
+		{"unclosed section", "[db\n", 1, 1},
+		{"unterminated quote", "name = \"abc\n", 1, 7},
+		{"missing key", "= value\n", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := parseINI([]byte(tt.input))
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected *SyntaxError, got %T: %v", err, err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("expected error at %d:%d, got %d:%d (%s)", tt.line, tt.column, syntaxErr.Line, syntaxErr.Column, syntaxErr.Reason)
+			}
+		})
+	}
+}
+
+type INIConfig struct {
+	Name string `env:"INI_NAME" json:"name"`
+	DB   struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+}
+
+func (c *INIConfig) SetDefaults() {
+}
+
+func TestINISource(t *testing.T) {
+	path := writeTestFile(t, "app.ini", `name = ini-app
+
+[db]
+host = db.internal
+port = 5432
+`)
+
+	var cfg INIConfig
+	if err := New(WithoutDotenv(), WithFileSources(INISource(path))).Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Name != "ini-app" {
+		t.Errorf("expected Name to be 'ini-app', got '%s'", cfg.Name)
+	}
+	if cfg.DB.Host != "db.internal" || cfg.DB.Port != 5432 {
+		t.Errorf("expected DB to be db.internal:5432, got %s:%d", cfg.DB.Host, cfg.DB.Port)
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// JSONSource returns a Source backed by the JSON file at path. Objects map
+// onto nested structs as described for WithFileSources.
+func JSONSource(path string) Source {
+	return &fileSource{path: path, parse: parseJSON}
+}
+
+func parseJSON(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var root map[string]any
+	if err := dec.Decode(&root); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column := position(string(data), int(syntaxErr.Offset))
+			return nil, &SyntaxError{Line: line, Column: column, Reason: syntaxErr.Error()}
+		}
+		return nil, err
+	}
+	return normalizeJSON(root).(map[string]any), nil
+}
+
+// normalizeJSON converts the scalars of a decoded JSON document to strings.
+func normalizeJSON(node any) any {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, value := range n {
+			n[key] = normalizeJSON(value)
+		}
+		return n
+	case []any:
+		for i, value := range n {
+			n[i] = normalizeJSON(value)
+		}
+		return n
+	case json.Number:
+		return n.String()
+	case bool:
+		return strconv.FormatBool(n)
+	case string, nil:
+		return n
+	default:
+		return fmt.Sprint(n)
+	}
+}
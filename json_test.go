@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type JSONConfig struct {
+	Name    string            `env:"JSON_NAME" json:"name"`
+	Port    int               `env:"JSON_PORT"`
+	Debug   bool              `env:"JSON_DEBUG" json:"debug"`
+	Timeout time.Duration     `env:"JSON_TIMEOUT" json:"timeout"`
+	Origins []string          `env:"JSON_ORIGINS" json:"origins"`
+	Labels  map[string]string `env:"JSON_LABELS" json:"labels"`
+	DB      struct {
+		Host string `env:"HOST" json:"host"`
+		Port int    `env:"PORT" json:"port"`
+	} `json:"database"`
+}
+
+func (c *JSONConfig) SetDefaults() {
+	c.Name = "default"
+	c.Port = 8080
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	return path
+}
+
+func TestJSONSource(t *testing.T) {
+	path := writeTestFile(t, "config.json", `{
+	"name": "json-app",
+	"JSON_PORT": 9000,
+	"debug": true,
+	"timeout": "5s",
+	"origins": ["a.example.com", "b.example.com"],
+	"labels": {"team": "core"},
+	"database": {"host": "db.internal", "port": 5432}
+}`)
+
+	os.Setenv("JSON_NAME", "env-app")
+	defer os.Unsetenv("JSON_NAME")
+
+	var cfg JSONConfig
+	if err := New(WithoutDotenv(), WithFileSources(JSONSource(path))).Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Name != "env-app" {
+		t.Errorf("expected Name to be 'env-app' (env overrides JSON), got '%s'", cfg.Name)
+	}
+	if cfg.Port != 9000 {
+		t.Errorf("expected Port to be 9000 (by env tag name), got %d", cfg.Port)
+	}
+	if !cfg.Debug {
+		t.Error("expected Debug to be true")
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("expected Timeout to be 5s, got %v", cfg.Timeout)
+	}
+	if expected := []string{"a.example.com", "b.example.com"}; !reflect.DeepEqual(cfg.Origins, expected) {
+		t.Errorf("expected Origins to be %v, got %v", expected, cfg.Origins)
+	}
+	if expected := map[string]string{"team": "core"}; !reflect.DeepEqual(cfg.Labels, expected) {
+		t.Errorf("expected Labels to be %v, got %v", expected, cfg.Labels)
+	}
+	if cfg.DB.Host != "db.internal" || cfg.DB.Port != 5432 {
+		t.Errorf("expected DB to be db.internal:5432, got %s:%d", cfg.DB.Host, cfg.DB.Port)
+	}
+}
+
+func TestJSONSourceBelowDotenv(t *testing.T) {
+	path := writeTestFile(t, "config.json", `{"JSON_PORT": 9000}`)
+	dotenv := writeTestFile(t, "app.env", "JSON_PORT=3000\n")
+
+	var cfg JSONConfig
+	loader := New(WithoutEnvMutation(), WithEnvFiles(dotenv), WithFileSources(JSONSource(path)))
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.Port != 3000 {
+		t.Errorf("expected Port to be 3000 (from .env), got %d", cfg.Port)
+	}
+}
+
+func TestJSONSourceInvalidFile(t *testing.T) {
+	path := writeTestFile(t, "config.json", `{"name": `)
+
+	var cfg JSONConfig
+	err := New(WithoutDotenv(), WithFileSources(JSONSource(path))).Load(&cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid JSON, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds the current version of a config so that it can be replaced
+// while other goroutines read it. Readers call Get and must treat the
+// returned config as immutable; writers Store a new one, typically from the
+// onChange function of Watch:
+//
+//	live := config.NewLive(cfg)
+//	err := config.Watch(ctx, cfg, func(_, new *AppConfig) { live.Store(new) })
+type Live[T any] struct {
+	current atomic.Pointer[T]
+
+	mu   sync.Mutex
+	subs map[chan Change[T]]struct{}
+}
+
+// Change is sent to subscribers of a Live when its config is replaced.
+type Change[T any] struct {
+	Old *T
+	New *T
+}
+
+// NewLive returns a Live holding cfg.
+func NewLive[T any](cfg *T) *Live[T] {
+	l := &Live[T]{subs: map[chan Change[T]]struct{}{}}
+	l.current.Store(cfg)
+	return l
+}
+
+// Get returns the current config.
+func (l *Live[T]) Get() *T {
+	return l.current.Load()
+}
+
+// Store replaces the current config with cfg, notifies subscribers and
+// returns the previous config.
+func (l *Live[T]) Store(cfg *T) *T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	old := l.current.Swap(cfg)
+	for ch := range l.subs {
+		notify(ch, Change[T]{Old: old, New: cfg})
+	}
+	return old
+}
+
+// Subscribe returns a channel that receives a Change for every Store, and a
+// function that ends the subscription and closes the channel. A subscriber
+// that falls behind receives a single Change spanning the changes it
+// missed, from the oldest Old to the latest New.
+func (l *Live[T]) Subscribe() (<-chan Change[T], func()) {
+	ch := make(chan Change[T], 1)
+
+	l.mu.Lock()
+	l.subs[ch] = struct{}{}
+	l.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			delete(l.subs, ch)
+			close(ch)
+		})
+	}
+}
+
+// notify sends c on ch without blocking. Since only Store sends, under the
+// mutex, a full buffer can always be replaced.
+func notify[T any](ch chan Change[T], c Change[T]) {
+	select {
+	case ch <- c:
+		return
+	default:
+	}
+	select {
+	case pending := <-ch:
+		c.Old = pending.Old
+	default:
+	}
+	ch <- c
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"sync"
+	"testing"
+)
+
+type LiveConfig struct {
+	Version int
+}
+
+func TestLiveStoreAndGet(t *testing.T) {
+	first := &LiveConfig{Version: 1}
+	live := NewLive(first)
+
+	if live.Get() != first {
+		t.Fatal("expected Get to return the initial config")
+	}
+
+	second := &LiveConfig{Version: 2}
+	if old := live.Store(second); old != first {
+		t.Errorf("expected Store to return the previous config, got %+v", old)
+	}
+	if live.Get() != second {
+		t.Errorf("expected Get to return the stored config, got %+v", live.Get())
+	}
+}
+
+func TestLiveSubscribe(t *testing.T) {
+	live := NewLive(&LiveConfig{Version: 1})
+	changes, cancel := live.Subscribe()
+
+	live.Store(&LiveConfig{Version: 2})
+	c := <-changes
+	if c.Old.Version != 1 || c.New.Version != 2 {
+		t.Errorf("expected a change from 1 to 2, got %d to %d", c.Old.Version, c.New.Version)
+	}
+
+	// a subscriber that falls behind gets the changes coalesced
+	live.Store(&LiveConfig{Version: 3})
+	live.Store(&LiveConfig{Version: 4})
+	c = <-changes
+	if c.Old.Version != 2 || c.New.Version != 4 {
+		t.Errorf("expected a change from 2 to 4, got %d to %d", c.Old.Version, c.New.Version)
+	}
+
+	cancel()
+	if _, ok := <-changes; ok {
+		t.Error("expected the channel to be closed after cancel")
+	}
+	cancel()
+	live.Store(&LiveConfig{Version: 5})
+}
+
+func TestLiveConcurrentAccess(t *testing.T) {
+	live := NewLive(&LiveConfig{Version: 0})
+	changes, cancel := live.Subscribe()
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if live.Get() == nil {
+					t.Error("expected Get never to return nil")
+					return
+				}
+			}
+		}()
+	}
+	for i := 1; i <= 100; i++ {
+		live.Store(&LiveConfig{Version: i})
+	}
+	wg.Wait()
+
+	c := <-changes
+	if c.New.Version != 100 {
+		t.Errorf("expected the latest change to be version 100, got %d", c.New.Version)
+	}
+}
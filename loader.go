@@ -0,0 +1,469 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Loader loads configuration structs from an ordered chain of sources, by
+// default the environment and dotenv files.
+// Create one with New; a Loader is safe to reuse across calls to Load.
+type Loader struct {
+	envFiles []string
+	// envVar names the variable selecting the dotenv cascade, if enabled
+	envVar      string
+	envDir      string
+	prefix      string
+	validators  map[string]ValidatorFunc
+	parsers     map[reflect.Type]ParserFunc
+	strict      bool
+	setenv      bool
+	lookup      func(key string) (string, bool)
+	sources     []Source
+	fileSources []Source
+	dotenvOpts  []DotenvOption
+	flagSet     *flag.FlagSet
+	flagArgs    []string
+	// fileIndirection enables _FILE variables for every field
+	fileIndirection bool
+	watchInterval   time.Duration
+	watchDebounce   time.Duration
+	onReloadError   func(error)
+
+	mu sync.Mutex
+	// exported holds the dotenv values this Loader wrote to the process
+	// environment, which later loads still read from their files
+	exported map[string]string
+}
+
+// New returns a Loader configured by opts. Without options it behaves like
+// the package-level Load: it reads .env from the working directory, looks up
+// variables with os.LookupEnv and parses strictly.
+func New(opts ...Option) *Loader {
+	l := &Loader{
+		envFiles:      []string{".env"},
+		validators:    map[string]ValidatorFunc{},
+		parsers:       map[reflect.Type]ParserFunc{},
+		strict:        true,
+		setenv:        true,
+		lookup:        os.LookupEnv,
+		watchInterval: time.Second,
+		watchDebounce: 500 * time.Millisecond,
+		onReloadError: func(err error) {
+			log.Printf("config: reload failed: %v", err)
+		},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Load applies the defaults of cfg, overrides them with values from the
+// command line flags and sources, and validates the result.
+func (l *Loader) Load(cfg DefaultSetter) error {
+	_, err := l.LoadExplain(cfg)
+	return err
+}
+
+// LoadExplain loads cfg like Load and also reports where the value of each
+// field came from.
+func (l *Loader) LoadExplain(cfg DefaultSetter) (Provenance, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config must be a pointer to a struct, got %T", cfg)
+	}
+
+	sources := l.sourceChain()
+	for _, src := range slices.Backward(sources) {
+		if r, ok := src.(Refresher); ok {
+			if err := r.Refresh(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cfg.SetDefaults()
+
+	flags, err := l.parseFlags(v.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	st := &loadState{sources: sources, flags: flags}
+	if err := l.loadStruct(v.Elem(), st); err != nil {
+		return nil, err
+	}
+
+	if l.setenv {
+		l.export(sources)
+	}
+
+	if err := l.validate(v.Elem()); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	return st.origins, nil
+}
+
+// sourceChain returns the sources consulted by Load, highest precedence
+// first: those given to WithSources, or else the environment followed by the
+// dotenv files and then any file sources.
+func (l *Loader) sourceChain() []Source {
+	if l.sources != nil {
+		return l.sources
+	}
+	files := l.dotenvFiles()
+	dotenv := make([]Source, len(files))
+	// references in a file resolve against the files below it, which are
+	// refreshed first
+	for i := len(files) - 1; i >= 0; i-- {
+		opts := []DotenvOption{DotenvLookup(l.lookupEnv), dotenvFallback(dotenv[i+1:])}
+		dotenv[i] = DotenvSource(files[i], append(opts, l.dotenvOpts...)...)
+	}
+
+	sources := []Source{lookupSource{name: "env", lookup: l.lookupEnv}}
+	sources = append(sources, dotenv...)
+	return append(sources, l.fileSources...)
+}
+
+// dotenvFiles returns the dotenv files to read, highest precedence first.
+func (l *Loader) dotenvFiles() []string {
+	files := l.envFiles
+	if l.envVar != "" {
+		files = l.dotenvCascade()
+	}
+	if l.envDir == "" {
+		return files
+	}
+	resolved := make([]string, len(files))
+	for i, filename := range files {
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(l.envDir, filename)
+		}
+		resolved[i] = filename
+	}
+	return resolved
+}
+
+// lookupEnv looks key up with l.lookup, skipping the dotenv values this
+// Loader exported so that they keep the precedence and origin of their file.
+func (l *Loader) lookupEnv(key string) (string, bool) {
+	value, ok := l.lookup(key)
+	if !ok {
+		return "", false
+	}
+	l.mu.Lock()
+	exported, isExported := l.exported[key]
+	l.mu.Unlock()
+	if isExported && exported == value {
+		return "", false
+	}
+	return value, true
+}
+
+// export writes the values of the dotenv sources to the process
+// environment, highest precedence first. Variables set by anything other
+// than this Loader are left alone.
+func (l *Loader) export(sources []Source) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.exported == nil {
+		l.exported = map[string]string{}
+	}
+	set := map[string]bool{}
+	for _, src := range sources {
+		d, ok := src.(*dotenvSource)
+		if !ok {
+			continue
+		}
+		d.mu.RLock()
+		for key, value := range d.values {
+			if set[key] {
+				continue
+			}
+			current, exists := os.LookupEnv(key)
+			if prev, ours := l.exported[key]; exists && !(ours && current == prev) {
+				continue
+			}
+			os.Setenv(key, value)
+			l.exported[key] = value
+			set[key] = true
+		}
+		d.mu.RUnlock()
+	}
+}
+
+// dotenvCascade returns the cascade of dotenv files for the environment
+// named by l.envVar.
+func (l *Loader) dotenvCascade() []string {
+	env, ok := l.lookupEnv(l.envVar)
+	if !ok {
+		// the environment may be chosen in .env itself; errors in the file
+		// are reported when it is read as a source
+		base := ".env"
+		if l.envDir != "" {
+			base = filepath.Join(l.envDir, base)
+		}
+		values, _, _ := readEnvFile(base, dotenvConfig{lookup: l.lookupEnv})
+		env = values[l.envVar]
+	}
+	if env == "" {
+		return []string{".env.local", ".env"}
+	}
+	return []string{".env." + env + ".local", ".env.local", ".env." + env, ".env"}
+}
+
+func (l *Loader) validate(v reflect.Value) error {
+	var errs ValidationErrors
+	if err := l.validateStruct(v, &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (l *Loader) validator(name string) (ValidatorFunc, bool) {
+	if fn, ok := l.validators[name]; ok {
+		return fn, true
+	}
+	fn, ok := validators[name]
+	return fn, ok
+}
+
+func (l *Loader) parser(t reflect.Type) (ParserFunc, bool) {
+	if fn, ok := l.parsers[t]; ok {
+		return fn, true
+	}
+	fn, ok := parsers[t]
+	return fn, ok
+}
+
+// loadState holds the inputs and provenance of a single call to Load.
+type loadState struct {
+	sources []Source
+	// flags holds the values of flags set on the command line, by name
+	flags   map[string]string
+	origins Provenance
+}
+
+// loadStruct populates the fields of v, recursing into nested and embedded
+// structs, and records where the value of every field came from.
+func (l *Loader) loadStruct(v reflect.Value, st *loadState) error {
+	return walkFields(v, l.prefix, func(fi fieldInfo) error {
+		if fi.nested || (fi.key == "" && fi.flag == "") {
+			return nil
+		}
+		origin, err := l.loadField(fi, st)
+		if err != nil {
+			return err
+		}
+		st.origins = append(st.origins, origin)
+		return nil
+	})
+}
+
+// loadField sets a single field from its flag, which takes precedence, the
+// first source with a value, or the file named by its _FILE variable, and
+// returns the origin of its value.
+func (l *Loader) loadField(fi fieldInfo, st *loadState) (Origin, error) {
+	defaults := Origin{Field: fi.path, Key: fi.key, Source: "defaults"}
+
+	var value any
+	var origin Origin
+	if arg, ok := st.flags[fi.flag]; ok {
+		value, origin = arg, Origin{Field: fi.path, Key: "-" + fi.flag, Source: "flag"}
+	} else if fi.key != "" {
+		found, src, ok := lookupSources(st.sources, fi.key, fi.keys)
+		switch {
+		case ok:
+			value, origin = found, sourceOrigin(fi, src)
+			// dotenv values were expanded when the file was read, and the
+			// env source skips those this Loader exported
+			if _, isDotenv := src.(*dotenvSource); fi.opts.has("expand") && !isDotenv {
+				var err error
+				if value, err = expandValue(value, st.sources); err != nil {
+					return origin, fmt.Errorf("failed to expand %s: %w", origin.Key, err)
+				}
+			}
+		case l.fileIndirection || fi.opts.has("file"):
+			contents, fileOrigin, ok, err := lookupFile(st.sources, fi)
+			if err != nil || !ok {
+				return defaults, err
+			}
+			value, origin = contents, fileOrigin
+		default:
+			return defaults, nil
+		}
+	}
+
+	// an empty value keeps the default
+	if value == nil || value == "" || !fi.value.CanSet() {
+		return defaults, nil
+	}
+
+	err := l.setRaw(fi.value, fi.field, value)
+	if err == nil {
+		return origin, nil
+	}
+	if errors.Is(err, errUnsupportedType) {
+		return origin, err
+	}
+	if l.strict {
+		raw := fmt.Sprint(value)
+		if fi.secret {
+			// decoder errors quote the value or one of its elements
+			raw, err = redactedValue, errSecretValue
+		}
+		return origin, &ParseError{Key: origin.Key, Field: fi.path, Value: raw, Type: fi.field.Type, Err: err}
+	}
+	return defaults, nil
+}
+
+// fieldInfo describes a struct field visited by walkFields.
+type fieldInfo struct {
+	field reflect.StructField
+	value reflect.Value
+	// path is the Go path of the field, e.g. DB.Port
+	path string
+	// key is the variable name including prefixes, empty without an env tag
+	key string
+	// keys is the path of the field within structured sources
+	keys []string
+	flag string
+	// opts holds the options of the env tag, e.g. secret
+	opts tagOptions
+	// nested is set for structs whose fields are visited in turn
+	nested bool
+	// secret is set for fields tagged as secret and for every field of a
+	// nested struct tagged as secret
+	secret bool
+}
+
+// walkFields calls fn for every field of the struct v, then recurses into
+// nested and embedded structs. prefix is prepended to every env tag.
+func walkFields(v reflect.Value, prefix string, fn func(fieldInfo) error) error {
+	return walkStruct(v, prefix, "", nil, false, fn)
+}
+
+func walkStruct(v reflect.Value, prefix, path string, keys []string, secret bool, fn func(fieldInfo) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fi := fieldInfo{
+			field:  field,
+			value:  v.Field(i),
+			path:   path + field.Name,
+			flag:   field.Tag.Get("flag"),
+			nested: isNestedStruct(field),
+		}
+		envTag, opts := parseEnvTag(field)
+		fi.opts = opts
+		fi.secret = secret || field.Tag.Get("secret") == "true" || opts.has("secret")
+		if envTag != "" {
+			fi.key = prefix + envTag
+		}
+		if fi.nested {
+			fi.keys = keys
+			if name := treeName(field, field.Name); name != "" {
+				fi.keys = appendKey(keys, name)
+			}
+		} else {
+			fi.keys = appendKey(keys, treeName(field, envTag))
+		}
+		if err := fn(fi); err != nil {
+			return err
+		}
+		if fi.nested {
+			if err := walkStruct(fi.value, prefix+structPrefix(field), fi.path+".", fi.keys, fi.secret, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tagOptions are the comma-separated options that follow the variable name
+// in an env tag.
+type tagOptions []string
+
+func (o tagOptions) has(name string) bool {
+	return slices.Contains(o, name)
+}
+
+// parseEnvTag splits the env tag of field into the variable name and its
+// options, e.g. env:"API_KEY,secret".
+func parseEnvTag(field reflect.StructField) (string, tagOptions) {
+	name, opts, _ := strings.Cut(field.Tag.Get("env"), ",")
+	if opts == "" {
+		return name, nil
+	}
+	return name, strings.Split(opts, ",")
+}
+
+// isNestedStruct reports whether field is a struct that groups further
+// config fields rather than a value read from a single variable or flag.
+// Untagged structs such as time.Time and url.URL, which format themselves
+// and contain no tagged fields, are values.
+func isNestedStruct(field reflect.StructField) bool {
+	if field.Type.Kind() != reflect.Struct || field.Tag.Get("env") != "" || field.Tag.Get("flag") != "" {
+		return false
+	}
+	return hasTaggedFields(field.Type) || !isFormattable(field.Type)
+}
+
+// hasTaggedFields reports whether the struct type t, or a struct nested in
+// it, has a field with an env or flag tag.
+func hasTaggedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("env") != "" || field.Tag.Get("flag") != "" {
+			return true
+		}
+		if field.Type.Kind() == reflect.Struct && hasTaggedFields(field.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// structPrefix returns the env prefix for a nested struct field: the
+// prefix tag if present, nothing for embedded structs, otherwise the field
+// name converted to upper snake case, e.g. HTTPServer becomes HTTP_SERVER_.
+func structPrefix(field reflect.StructField) string {
+	if prefix, ok := field.Tag.Lookup("prefix"); ok {
+		return prefix
+	}
+	if field.Anonymous {
+		return ""
+	}
+	return toEnvName(field.Name) + "_"
+}
+
+func toEnvName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prev != '_' && (!unicode.IsUpper(prev) || nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
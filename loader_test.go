@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type LoaderConfig struct {
+	Name  string          `env:"NAME" validate:"required,even_length"`
+	Port  int             `env:"PORT"`
+	Owner loaderTestOwner `env:"OWNER"`
+}
+
+type loaderTestOwner struct {
+	Team string
+}
+
+func (c *LoaderConfig) SetDefaults() {
+	c.Name = "loader"
+	c.Port = 8080
+}
+
+func mapLookup(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func evenLength(field reflect.StructField, value reflect.Value, param string) error {
+	if len(value.String())%2 != 0 {
+		return fmt.Errorf("field '%s' must have an even length", field.Name)
+	}
+	return nil
+}
+
+func parseOwner(value string) (any, error) {
+	return loaderTestOwner{Team: strings.ToUpper(value)}, nil
+}
+
+func TestLoaderWithOptions(t *testing.T) {
+	loader := New(
+		WithoutDotenv(),
+		WithPrefix("APP_"),
+		WithLookup(mapLookup(map[string]string{
+			"APP_NAME":  "services",
+			"APP_PORT":  "9090",
+			"APP_OWNER": "platform",
+			"PORT":      "1",
+		})),
+		WithValidators(map[string]ValidatorFunc{"even_length": evenLength}),
+		WithParser(reflect.TypeOf(loaderTestOwner{}), parseOwner),
+	)
+
+	var cfg LoaderConfig
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Name != "services" {
+		t.Errorf("expected Name to be 'services', got '%s'", cfg.Name)
+	}
+	if cfg.Port != 9090 {
+		t.Errorf("expected Port to be 9090 (from APP_PORT), got %d", cfg.Port)
+	}
+	if cfg.Owner.Team != "PLATFORM" {
+		t.Errorf("expected Owner.Team to be 'PLATFORM', got '%s'", cfg.Owner.Team)
+	}
+}
+
+func TestLoaderValidatorsAreScoped(t *testing.T) {
+	loader := New(
+		WithoutDotenv(),
+		WithLookup(mapLookup(map[string]string{"NAME": "odd"})),
+		WithValidators(map[string]ValidatorFunc{"even_length": evenLength}),
+	)
+
+	var cfg LoaderConfig
+	err := loader.Load(&cfg)
+	expectedErr := "validation error: NAME: field 'Name' must have an even length"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error '%s', got '%v'", expectedErr, err)
+	}
+
+	os.Setenv("NAME", "even")
+	defer os.Unsetenv("NAME")
+	err = Load(&cfg, WithoutDotenv())
+	expectedErr = "no validator registered for rule 'even_length'"
+	if err == nil || !strings.Contains(err.Error(), expectedErr) {
+		t.Errorf("expected error containing '%s', got '%v'", expectedErr, err)
+	}
+}
+
+func TestLoaderWithEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := dir + "/first.env"
+	second := dir + "/second.env"
+	if err := os.WriteFile(first, []byte("LOADER_FILE_A=first\n"), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", first, err)
+	}
+	if err := os.WriteFile(second, []byte("LOADER_FILE_A=second\nLOADER_FILE_B=second\n"), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", second, err)
+	}
+	defer func() {
+		os.Unsetenv("LOADER_FILE_A")
+		os.Unsetenv("LOADER_FILE_B")
+	}()
+
+	var cfg struct {
+		DefaultsConfig
+		A string `env:"LOADER_FILE_A"`
+		B string `env:"LOADER_FILE_B"`
+	}
+	if err := New(WithEnvFiles(first, second)).Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.A != "first" {
+		t.Errorf("expected A to be 'first' (earlier file wins), got '%s'", cfg.A)
+	}
+	if cfg.B != "second" {
+		t.Errorf("expected B to be 'second', got '%s'", cfg.B)
+	}
+}
+
+// DefaultsConfig provides a no-op SetDefaults for ad-hoc test structs.
+type DefaultsConfig struct{}
+
+func (DefaultsConfig) SetDefaults() {}
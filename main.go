@@ -1,103 +1,111 @@
 package config
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"reflect"
-	"strconv"
-	"strings"
 )
 
 type DefaultSetter interface {
 	SetDefaults()
 }
 
+// loadEnvFile sets every variable from the dotenv file that is not already
+// present in the process environment.
 func loadEnvFile(filename string) error {
-	file, err := os.Open(filename)
+	values, _, err := readEnvFile(filename, dotenvConfig{})
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
 		return err
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		if len(value) >= 2 {
-			if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-				(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-				value = value[1 : len(value)-1]
-			}
-		}
+	setenvMissing(values)
+	return nil
+}
 
+func setenvMissing(values map[string]string) {
+	for key, value := range values {
 		if _, exists := os.LookupEnv(key); !exists {
 			os.Setenv(key, value)
 		}
 	}
+}
 
-	return scanner.Err()
+// dotenvConfig controls how readEnvFile parses a dotenv file.
+type dotenvConfig struct {
+	// strict returns the first syntax error instead of skipping the line
+	strict bool
+	// warn receives the syntax errors skipped in lenient mode
+	warn func(*DotenvSyntaxError)
+	// lookup resolves references before the file's earlier lines, by
+	// default os.LookupEnv
+	lookup func(key string) (string, bool)
+	// fallback resolves references after the file's earlier lines, such as
+	// those to lower precedence files of a cascade
+	fallback func(key string) (string, bool)
 }
 
-func Load[T DefaultSetter](cfg T) error {
-	if err := loadEnvFile(".env"); err != nil {
-		return fmt.Errorf("failed to load .env file: %w", err)
+// readEnvFile parses a dotenv file without touching the environment, and
+// returns its values along with the line number each was set on. A missing
+// file yields no values. If a key repeats, its first value wins. Unless
+// single-quoted, values may refer to variables found by cfg.lookup, set on
+// earlier lines or found by cfg.fallback, see expand.
+func readEnvFile(filename string, cfg dotenvConfig) (map[string]string, map[string]int, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
 	}
+	return decodeEnvFile(filename, string(data), cfg)
+}
 
-	cfg.SetDefaults()
+// decodeEnvFile is readEnvFile for the contents of filename.
+func decodeEnvFile(filename, data string, cfg dotenvConfig) (map[string]string, map[string]int, error) {
+	lookup := cfg.lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
 
-	v := reflect.ValueOf(cfg).Elem()
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
-		envTag := field.Tag.Get("env")
-		if envTag == "" {
-			continue
+	entries, errs := parseDotenv(data)
+	for _, syntaxErr := range errs {
+		syntaxErr.File = filename
+		if cfg.strict {
+			return nil, nil, syntaxErr
 		}
-		if envValue, ok := os.LookupEnv(envTag); ok {
-			f := v.Field(i)
-			if !f.CanSet() {
-				continue
-			}
-			switch f.Kind() {
-			case reflect.String:
-				// skip if empty string
-				if envValue == "" {
-					continue
+		if cfg.warn != nil {
+			cfg.warn(syntaxErr)
+		}
+	}
+
+	values := map[string]string{}
+	lines := map[string]int{}
+	for _, entry := range entries {
+		value := entry.value
+		if entry.quote != '\'' {
+			var err error
+			value, err = expand(value, func(name string) (string, bool) {
+				if value, ok := lookup(name); ok {
+					return value, true
 				}
-				f.SetString(envValue)
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if intVal, err := strconv.ParseInt(envValue, 10, 64); err == nil {
-					f.SetInt(intVal)
+				if value, ok := values[name]; ok {
+					return value, true
 				}
-			case reflect.Bool:
-				if boolVal, err := strconv.ParseBool(envValue); err == nil {
-					f.SetBool(boolVal)
+				if cfg.fallback != nil {
+					return cfg.fallback(name)
 				}
-			default:
-				return fmt.Errorf("unsupported type: %s", f.Kind())
+				return "", false
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", entry.line, err)
 			}
 		}
+		values[entry.key] = value
+		lines[entry.key] = entry.line
 	}
+	return values, lines, nil
+}
 
-	if err := ValidateStruct(cfg); err != nil {
-		return fmt.Errorf("validation error: %w", err)
-	}
-
-	return nil
+// Load populates cfg from the environment and .env file, then validates it.
+// It is a shorthand for New(opts...).Load(cfg).
+func Load[T DefaultSetter](cfg T, opts ...Option) error {
+	return New(opts...).Load(cfg)
 }
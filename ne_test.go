@@ -32,7 +32,7 @@ func TestNeValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid NeField, got nil")
 	}
-	expectedErr := "validation error: field 'NeField' must not be equal to 'forbidden'"
+	expectedErr := "validation error: TEST_NE: field 'NeField' must not be equal to 'forbidden'"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+type NestedDBConfig struct {
+	Host string `env:"HOST" validate:"required"`
+	Port int    `env:"PORT" validate:"min=1"`
+}
+
+type NestedHTTPConfig struct {
+	Addr string `env:"ADDR"`
+}
+
+type NestedCommon struct {
+	LogLevel string `env:"LOG_LEVEL"`
+}
+
+type NestedConfig struct {
+	NestedCommon
+	DB         NestedDBConfig
+	HTTPServer NestedHTTPConfig
+	Cache      NestedDBConfig `prefix:"REDIS_"`
+}
+
+func (c *NestedConfig) SetDefaults() {
+	c.DB.Host = "localhost"
+	c.DB.Port = 5432
+	c.Cache.Host = "localhost"
+	c.Cache.Port = 6379
+}
+
+func TestLoadNestedStructs(t *testing.T) {
+	os.Setenv("DB_HOST", "db.internal")
+	os.Setenv("HTTP_SERVER_ADDR", ":8080")
+	os.Setenv("REDIS_PORT", "6380")
+	os.Setenv("LOG_LEVEL", "debug")
+	defer func() {
+		os.Unsetenv("DB_HOST")
+		os.Unsetenv("HTTP_SERVER_ADDR")
+		os.Unsetenv("REDIS_PORT")
+		os.Unsetenv("LOG_LEVEL")
+	}()
+
+	var cfg NestedConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.DB.Host != "db.internal" {
+		t.Errorf("expected DB.Host to be 'db.internal', got '%s'", cfg.DB.Host)
+	}
+	if cfg.DB.Port != 5432 {
+		t.Errorf("expected DB.Port to be 5432 (default), got %d", cfg.DB.Port)
+	}
+	if cfg.HTTPServer.Addr != ":8080" {
+		t.Errorf("expected HTTPServer.Addr to be ':8080', got '%s'", cfg.HTTPServer.Addr)
+	}
+	if cfg.Cache.Port != 6380 {
+		t.Errorf("expected Cache.Port to be 6380, got %d", cfg.Cache.Port)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("expected LogLevel to be 'debug', got '%s'", cfg.LogLevel)
+	}
+}
+
+func TestValidateNestedStructs(t *testing.T) {
+	var cfg NestedConfig
+	cfg.SetDefaults()
+	cfg.Cache.Host = ""
+
+	err := ValidateStruct(&cfg)
+	if err == nil {
+		t.Fatal("expected error for missing nested required field, got nil")
+	}
+	expectedErr := "REDIS_HOST: field 'Host' is required"
+	if err.Error() != expectedErr {
+		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
+	}
+}
+
+func TestToEnvName(t *testing.T) {
+	tests := map[string]string{
+		"DB":         "DB",
+		"Database":   "DATABASE",
+		"HTTPServer": "HTTP_SERVER",
+		"SMTPHost":   "SMTP_HOST",
+		"APIKey":     "API_KEY",
+	}
+	for name, expected := range tests {
+		if got := toEnvName(name); got != expected {
+			t.Errorf("toEnvName(%q): expected '%s', got '%s'", name, expected, got)
+		}
+	}
+}
@@ -34,7 +34,7 @@ func TestNotInValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid NotInField, got nil")
 	}
-	expectedErr := "validation error: field 'NotInField' must not be one of the following values: a|b|c"
+	expectedErr := "validation error: TEST_NOTIN: field 'NotInField' must not be one of the following values: a|b|c"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
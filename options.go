@@ -0,0 +1,181 @@
+package config
+
+import (
+	"flag"
+	"maps"
+	"reflect"
+	"time"
+)
+
+type Option func(*Loader)
+
+// WithEnvFiles replaces the dotenv files read by the Loader, by default
+// ".env". Earlier files take precedence, and references in a file's values
+// resolve against the files after it.
+func WithEnvFiles(filenames ...string) Option {
+	return func(l *Loader) {
+		l.envFiles = filenames
+		l.envVar = ""
+	}
+}
+
+// WithoutDotenv disables reading dotenv files altogether.
+func WithoutDotenv() Option {
+	return func(l *Loader) {
+		l.envFiles = nil
+		l.envVar = ""
+	}
+}
+
+// WithDotenvCascade replaces the dotenv files with a cascade selected by the
+// environment named in variable, e.g. APP_ENV. From highest precedence to
+// lowest, the files are .env.{env}.local, .env.local, .env.{env} and .env.
+// The variable is looked up on every Load, first in the environment and
+// then in .env; without it only .env.local and .env are read.
+func WithDotenvCascade(variable string) Option {
+	return func(l *Loader) {
+		l.envVar = variable
+	}
+}
+
+// WithEnvDir resolves relative dotenv file names against dir instead of the
+// working directory.
+func WithEnvDir(dir string) Option {
+	return func(l *Loader) {
+		l.envDir = dir
+	}
+}
+
+// WithStrictDotenv makes Load fail with a *DotenvSyntaxError when a dotenv
+// file is malformed, instead of skipping the lines in error.
+func WithStrictDotenv() Option {
+	return func(l *Loader) {
+		l.dotenvOpts = append(l.dotenvOpts, DotenvStrict())
+	}
+}
+
+// WithDotenvWarnings passes every syntax error in the dotenv files to fn
+// when they are parsed leniently, the default.
+func WithDotenvWarnings(fn func(*DotenvSyntaxError)) Option {
+	return func(l *Loader) {
+		l.dotenvOpts = append(l.dotenvOpts, DotenvWarnings(fn))
+	}
+}
+
+// WithPrefix prepends prefix to every variable name, so that with
+// WithPrefix("APP_") a field tagged env:"PORT" is read from APP_PORT.
+func WithPrefix(prefix string) Option {
+	return func(l *Loader) {
+		l.prefix = prefix
+	}
+}
+
+// WithValidators adds validators available only to this Loader. They take
+// precedence over validators registered with RegisterValidator.
+func WithValidators(fns map[string]ValidatorFunc) Option {
+	return func(l *Loader) {
+		maps.Copy(l.validators, fns)
+	}
+}
+
+// WithParser registers a parser for t available only to this Loader. It
+// takes precedence over parsers registered with RegisterParser.
+func WithParser(t reflect.Type, fn ParserFunc) Option {
+	return func(l *Loader) {
+		l.parsers[t] = fn
+	}
+}
+
+// WithStrictParsing controls whether values that fail to parse are reported
+// as a *ParseError (the default) or silently ignored, leaving the field at
+// its default.
+func WithStrictParsing(strict bool) Option {
+	return func(l *Loader) {
+		l.strict = strict
+	}
+}
+
+// WithoutEnvMutation stops dotenv values being written to the process
+// environment with os.Setenv after they are loaded. Precedence is unchanged,
+// but os.Environ and child processes never see them.
+func WithoutEnvMutation() Option {
+	return func(l *Loader) {
+		l.setenv = false
+	}
+}
+
+// WithLookup replaces os.LookupEnv as the highest precedence source of
+// variables. Dotenv files are still consulted after it.
+func WithLookup(fn func(key string) (string, bool)) Option {
+	return func(l *Loader) {
+		l.lookup = fn
+	}
+}
+
+// WithFileSources adds structured config file sources, such as JSONSource,
+// below the environment and dotenv files. Earlier sources take precedence.
+// Each file is read on every Load, and a missing file provides no values.
+//
+// Fields are looked up by path: the json tag of each field from the root
+// struct down, falling back to the env tag for values and the field name for
+// nested structs. Names match case-insensitively.
+func WithFileSources(sources ...Source) Option {
+	return func(l *Loader) {
+		l.fileSources = append(l.fileSources, sources...)
+	}
+}
+
+// WithSources replaces the environment and dotenv files with sources,
+// consulted in order. WithEnvFiles, WithoutDotenv, WithLookup and
+// WithFileSources have no effect once sources are given.
+func WithSources(sources ...Source) Option {
+	return func(l *Loader) {
+		l.sources = sources
+	}
+}
+
+// WithFlags binds fields with a flag tag to command line flags on fs, which
+// take precedence over every source. Flags are defined on the first Load,
+// with usage text from the desc tag and defaults from SetDefaults, and args
+// are then parsed unless fs has been parsed already.
+func WithFlags(fs *flag.FlagSet, args []string) Option {
+	return func(l *Loader) {
+		l.flagSet = fs
+		l.flagArgs = args
+	}
+}
+
+// WithFileIndirection reads the value of any field whose variable is unset
+// from the file named by the same variable with a _FILE suffix, as used by
+// Docker and Kubernetes secrets. Individual fields can opt in with the file
+// option of the env tag instead, e.g. env:"API_KEY,file".
+func WithFileIndirection() Option {
+	return func(l *Loader) {
+		l.fileIndirection = true
+	}
+}
+
+// WithWatchInterval sets how often Watch polls files for changes. The
+// default is one second.
+func WithWatchInterval(d time.Duration) Option {
+	return func(l *Loader) {
+		l.watchInterval = d
+	}
+}
+
+// WithWatchDebounce sets how long files must stay unchanged before Watch
+// reloads, so that a burst of writes causes a single reload. The default is
+// 500ms.
+func WithWatchDebounce(d time.Duration) Option {
+	return func(l *Loader) {
+		l.watchDebounce = d
+	}
+}
+
+// WithReloadErrorHandler sets the function Watch reports failed reloads to.
+// By default they are logged with the log package.
+func WithReloadErrorHandler(fn func(error)) Option {
+	return func(l *Loader) {
+		l.onReloadError = fn
+	}
+}
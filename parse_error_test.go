@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+type ParseErrorConfig struct {
+	DB struct {
+		Port int `env:"PORT"`
+	}
+}
+
+func (c *ParseErrorConfig) SetDefaults() {
+	c.DB.Port = 8080
+}
+
+func TestParseErrorIsReturned(t *testing.T) {
+	os.Setenv("DB_PORT", "80a")
+	defer os.Unsetenv("DB_PORT")
+
+	var cfg ParseErrorConfig
+	err := Load(&cfg)
+	if err == nil {
+		t.Fatal("expected parse error, got nil")
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *ParseError, got %T: %v", err, err)
+	}
+	if parseErr.Key != "DB_PORT" {
+		t.Errorf("expected Key to be 'DB_PORT', got '%s'", parseErr.Key)
+	}
+	if parseErr.Field != "DB.Port" {
+		t.Errorf("expected Field to be 'DB.Port', got '%s'", parseErr.Field)
+	}
+	if parseErr.Value != "80a" {
+		t.Errorf("expected Value to be '80a', got '%s'", parseErr.Value)
+	}
+	if parseErr.Type.String() != "int" {
+		t.Errorf("expected Type to be 'int', got '%s'", parseErr.Type)
+	}
+	expectedErr := `invalid value '80a' for DB_PORT (field 'DB.Port', type int): strconv.ParseInt: parsing "80a": invalid syntax`
+	if err.Error() != expectedErr {
+		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
+	}
+}
+
+func TestLenientParsingKeepsDefault(t *testing.T) {
+	os.Setenv("DB_PORT", "80a")
+	defer os.Unsetenv("DB_PORT")
+
+	var cfg ParseErrorConfig
+	if err := Load(&cfg, WithStrictParsing(false)); err != nil {
+		t.Fatalf("expected lenient load to succeed, got error: %v", err)
+	}
+	if cfg.DB.Port != 8080 {
+		t.Errorf("expected DB.Port to be 8080 (default), got %d", cfg.DB.Port)
+	}
+}
+
+func TestEmptyValueKeepsDefault(t *testing.T) {
+	os.Setenv("DB_PORT", "")
+	defer os.Unsetenv("DB_PORT")
+
+	var cfg ParseErrorConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected empty value to be ignored, got error: %v", err)
+	}
+	if cfg.DB.Port != 8080 {
+		t.Errorf("expected DB.Port to be 8080 (default), got %d", cfg.DB.Port)
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"reflect"
+)
+
+type ParserFunc func(value string) (any, error)
+
+var parsers = map[reflect.Type]ParserFunc{}
+
+// RegisterParser teaches Load how to decode values of type t, for types that
+// cannot implement Decoder themselves. Registered parsers take precedence
+// over every built-in decoding rule.
+func RegisterParser(t reflect.Type, fn ParserFunc) {
+	parsers[t] = fn
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// testMoney stands in for a third-party type that cannot implement Decoder.
+type testMoney struct {
+	Cents int64
+}
+
+type ParserConfig struct {
+	Price  testMoney            `env:"TEST_PRICE"`
+	Prices map[string]testMoney `env:"TEST_PRICES"`
+}
+
+func (c *ParserConfig) SetDefaults() {
+}
+
+func init() {
+	RegisterParser(reflect.TypeOf(testMoney{}), func(value string) (any, error) {
+		var units, cents int64
+		if _, err := fmt.Sscanf(value, "%d.%02d", &units, &cents); err != nil {
+			return nil, err
+		}
+		return testMoney{Cents: units*100 + cents}, nil
+	})
+}
+
+func TestRegisteredParser(t *testing.T) {
+	os.Setenv("TEST_PRICE", "12.34")
+	os.Setenv("TEST_PRICES", "small:1.00,large:2.50")
+	defer func() {
+		os.Unsetenv("TEST_PRICE")
+		os.Unsetenv("TEST_PRICES")
+	}()
+
+	var cfg ParserConfig
+	if err := Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Price.Cents != 1234 {
+		t.Errorf("expected Price to be 1234 cents, got %d", cfg.Price.Cents)
+	}
+	if expected := map[string]testMoney{"small": {100}, "large": {250}}; !reflect.DeepEqual(cfg.Prices, expected) {
+		t.Errorf("expected Prices to be %v, got %v", expected, cfg.Prices)
+	}
+}
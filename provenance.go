@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Origin describes where the value of a field came from.
+type Origin struct {
+	// Field is the Go path of the field, e.g. DB.Port
+	Field string
+	// Key is the variable, flag or file key the value was read from
+	Key string
+	// Source is "flag", "env", "dotenv", "file", "dir", "defaults" or the
+	// name of a custom source
+	Source string
+	// File and Line locate dotenv values; File is also set for file and
+	// directory sources and names the file read through a _FILE variable
+	File string
+	Line int
+}
+
+func (o Origin) String() string {
+	switch {
+	case o.Source == "defaults":
+		return "defaults"
+	case o.Line > 0:
+		return fmt.Sprintf("%s %s (%s:%d)", o.Source, o.Key, o.File, o.Line)
+	case o.File != "":
+		return fmt.Sprintf("%s %s (%s)", o.Source, o.Key, o.File)
+	default:
+		return fmt.Sprintf("%s %s", o.Source, o.Key)
+	}
+}
+
+// Provenance lists the origin of every field bound by Load, in field order.
+type Provenance []Origin
+
+// Field returns the origin of the field at path, e.g. DB.Port.
+func (p Provenance) Field(path string) (Origin, bool) {
+	for _, o := range p {
+		if o.Field == path {
+			return o, true
+		}
+	}
+	return Origin{}, false
+}
+
+func (p Provenance) String() string {
+	var b strings.Builder
+	for _, o := range p {
+		fmt.Fprintf(&b, "%s: %s\n", o.Field, o)
+	}
+	return b.String()
+}
+
+func sourceOrigin(fi fieldInfo, src Source) Origin {
+	switch s := src.(type) {
+	case *dotenvSource:
+		return Origin{Field: fi.path, Key: fi.key, Source: "dotenv", File: s.path, Line: s.line(fi.key)}
+	case *dirSource:
+		return Origin{Field: fi.path, Key: fi.key, Source: "dir", File: s.file(fi.key)}
+	case *fileSource:
+		return Origin{Field: fi.path, Key: strings.Join(fi.keys, "."), Source: "file", File: s.path}
+	default:
+		return Origin{Field: fi.path, Key: fi.key, Source: src.Name()}
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type ProvenanceConfig struct {
+	Host    string `env:"PROV_HOST"`
+	Port    int    `env:"PROV_PORT" flag:"port"`
+	User    string `env:"PROV_USER"`
+	Region  string `env:"PROV_REGION" json:"region"`
+	Timeout string `env:"PROV_TIMEOUT"`
+	Name    string `env:"PROV_NAME"`
+}
+
+func (c *ProvenanceConfig) SetDefaults() {
+	c.Name = "default"
+}
+
+func TestLoadExplainReportsOrigins(t *testing.T) {
+	dir := t.TempDir()
+	dotenv := filepath.Join(dir, "app.env")
+	if err := os.WriteFile(dotenv, []byte("# comment\nPROV_HOST=env-file-host\n\nPROV_USER=dotenv\n"), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", dotenv, err)
+	}
+	jsonFile := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"region": "eu"}`), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", jsonFile, err)
+	}
+
+	loader := New(
+		WithoutEnvMutation(),
+		WithEnvFiles(dotenv),
+		WithLookup(mapLookup(map[string]string{"PROV_HOST": "env-host", "PROV_NAME": ""})),
+		WithFileSources(JSONSource(jsonFile), MapSource(map[string]string{"PROV_TIMEOUT": "5s"})),
+		WithFlags(flag.NewFlagSet("test", flag.ContinueOnError), []string{"-port", "9000"}),
+	)
+
+	var cfg ProvenanceConfig
+	provenance, err := loader.LoadExplain(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	tests := []struct {
+		field string
+		want  Origin
+	}{
+		{"Host", Origin{Field: "Host", Key: "PROV_HOST", Source: "env"}},
+		{"Port", Origin{Field: "Port", Key: "-port", Source: "flag"}},
+		{"User", Origin{Field: "User", Key: "PROV_USER", Source: "dotenv", File: dotenv, Line: 4}},
+		{"Region", Origin{Field: "Region", Key: "region", Source: "file", File: jsonFile}},
+		{"Timeout", Origin{Field: "Timeout", Key: "PROV_TIMEOUT", Source: "map"}},
+		{"Name", Origin{Field: "Name", Key: "PROV_NAME", Source: "defaults"}},
+	}
+	for _, tt := range tests {
+		got, ok := provenance.Field(tt.field)
+		if !ok {
+			t.Errorf("expected provenance for %s", tt.field)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("expected %s to come from %+v, got %+v", tt.field, tt.want, got)
+		}
+	}
+
+	if !strings.Contains(provenance.String(), "User: dotenv PROV_USER ("+dotenv+":4)") {
+		t.Errorf("unexpected provenance output:\n%s", provenance)
+	}
+}
+
+func TestLoadExplainNestedFields(t *testing.T) {
+	loader := New(WithoutDotenv(), WithLookup(mapLookup(map[string]string{"DB_HOST": "db"})))
+
+	var cfg NestedConfig
+	provenance, err := loader.LoadExplain(&cfg)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	origin, ok := provenance.Field("DB.Host")
+	if !ok {
+		t.Fatal("expected provenance for DB.Host")
+	}
+	if origin.Source != "env" || origin.Key != "DB_HOST" {
+		t.Errorf("expected DB.Host to come from env DB_HOST, got %s", origin)
+	}
+}
+
+func TestLoadExplainAfterEnvMutation(t *testing.T) {
+	os.Setenv("PROV_HOST", "env-host")
+	defer os.Unsetenv("PROV_HOST")
+	defer os.Unsetenv("PROV_USER")
+
+	dotenv := writeTestFile(t, ".env", "PROV_USER=dotenv\nPROV_HOST=dotenv-host\n")
+	loader := New(WithEnvFiles(dotenv))
+
+	for i := 1; i <= 2; i++ {
+		var cfg ProvenanceConfig
+		provenance, err := loader.LoadExplain(&cfg)
+		if err != nil {
+			t.Fatalf("expected valid config, got error: %v", err)
+		}
+		if origin, _ := provenance.Field("User"); origin.Source != "dotenv" || origin.Line != 1 {
+			t.Errorf("expected User to come from dotenv line 1 on load %d, got %s", i, origin)
+		}
+		if origin, _ := provenance.Field("Host"); origin.Source != "env" {
+			t.Errorf("expected Host to come from env on load %d, got %s", i, origin)
+		}
+	}
+	if os.Getenv("PROV_USER") != "dotenv" {
+		t.Errorf("expected PROV_USER to be exported as 'dotenv', got '%s'", os.Getenv("PROV_USER"))
+	}
+
+	if err := os.WriteFile(dotenv, []byte("PROV_USER=changed\n"), 0644); err != nil {
+		t.Fatalf("Failed to update %s: %v", dotenv, err)
+	}
+	var cfg ProvenanceConfig
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.User != "changed" {
+		t.Errorf("expected User to be 'changed', got '%s'", cfg.User)
+	}
+	if os.Getenv("PROV_USER") != "changed" {
+		t.Errorf("expected PROV_USER to be exported as 'changed', got '%s'", os.Getenv("PROV_USER"))
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// RedactedConfig is a view of a config struct that prints and logs every
+// field with secrets masked. Create one with Redacted.
+type RedactedConfig struct {
+	cfg any
+}
+
+// Redacted returns a view of cfg, a struct or a pointer to one, that is safe
+// to print or log. Fields tagged secret:"true" or env:"NAME,secret", and all
+// fields of nested structs tagged secret:"true", are masked unless empty.
+func Redacted(cfg any) RedactedConfig {
+	return RedactedConfig{cfg: cfg}
+}
+
+// Dump returns every field of cfg, one per line, with secrets masked.
+func Dump(cfg any) string {
+	return Redacted(cfg).String()
+}
+
+func (r RedactedConfig) String() string {
+	var b strings.Builder
+	for _, f := range r.fields() {
+		fmt.Fprintf(&b, "%s: %s\n", f.path, f.value)
+	}
+	return b.String()
+}
+
+// LogValue implements slog.LogValuer, logging each field as an attribute
+// named by its Go path, e.g. DB.Host.
+func (r RedactedConfig) LogValue() slog.Value {
+	fields := r.fields()
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.String(f.path, f.value)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+type redactedField struct {
+	path  string
+	value string
+}
+
+func (r RedactedConfig) fields() []redactedField {
+	v := reflect.ValueOf(r.cfg)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []redactedField
+	walkFields(v, "", func(fi fieldInfo) error {
+		if fi.nested || !fi.field.IsExported() {
+			return nil
+		}
+		value := formatValue(fi.value, fi.field)
+		if u, ok := urlValue(fi.value); ok {
+			value = u.Redacted()
+		}
+		if fi.secret && value != "" {
+			value = redactedValue
+		}
+		fields = append(fields, redactedField{path: fi.path, value: value})
+		return nil
+	})
+	return fields
+}
+
+// urlValue returns the URL held by v, a url.URL or a non-nil pointer to
+// one, so that its password can be masked even in fields not marked secret.
+func urlValue(v reflect.Value) (*url.URL, bool) {
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Type() != urlType || !v.CanInterface() {
+		return nil, false
+	}
+	u := v.Interface().(url.URL)
+	return &u, true
+}
+
+// errSecretValue replaces the decoder error in a ParseError for a secret
+// field, since decoders quote the value or the element that failed.
+var errSecretValue = errors.New("secret value cannot be decoded")
@@ -32,7 +32,7 @@ func TestRegexpValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid username, got nil")
 	}
-	expectedErr := "validation error: field 'Username' must match the pattern '^[a-zA-Z0-9]+$'"
+	expectedErr := "validation error: TEST_USERNAME: field 'Username' must match the pattern '^[a-zA-Z0-9]+$'"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
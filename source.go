@@ -0,0 +1,166 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Source supplies raw configuration values by key. A Loader consults its
+// sources in order and uses the first one that has a value.
+type Source interface {
+	Name() string
+	Lookup(key string) (string, bool)
+}
+
+// Refresher is implemented by sources that cache external state, such as
+// the contents of a file. A Loader calls Refresh on every such source at the
+// start of each Load, lowest precedence first.
+type Refresher interface {
+	Refresh() error
+}
+
+type lookupSource struct {
+	name   string
+	lookup func(key string) (string, bool)
+}
+
+func (s lookupSource) Name() string {
+	return s.name
+}
+
+func (s lookupSource) Lookup(key string) (string, bool) {
+	return s.lookup(key)
+}
+
+// EnvSource returns a Source backed by the process environment.
+func EnvSource() Source {
+	return lookupSource{name: "env", lookup: os.LookupEnv}
+}
+
+// MapSource returns a Source backed by a fixed set of values.
+func MapSource(values map[string]string) Source {
+	return lookupSource{name: "map", lookup: func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}}
+}
+
+// DotenvOption configures a DotenvSource.
+type DotenvOption func(*dotenvSource)
+
+// DotenvStrict makes a malformed dotenv file fail the Load with a
+// *DotenvSyntaxError instead of skipping the lines in error.
+func DotenvStrict() DotenvOption {
+	return func(s *dotenvSource) {
+		s.strict = true
+	}
+}
+
+// DotenvWarnings passes every syntax error in a leniently parsed dotenv
+// file to fn, on each Load.
+func DotenvWarnings(fn func(*DotenvSyntaxError)) DotenvOption {
+	return func(s *dotenvSource) {
+		s.warn = fn
+	}
+}
+
+// DotenvLookup sets the function that references in the file's values are
+// resolved with before the file's own earlier lines, by default
+// os.LookupEnv.
+func DotenvLookup(fn func(key string) (string, bool)) DotenvOption {
+	return func(s *dotenvSource) {
+		s.lookup = fn
+	}
+}
+
+// dotenvFallback resolves references that the file cannot resolve itself
+// against sources, in order.
+func dotenvFallback(sources []Source) DotenvOption {
+	return func(s *dotenvSource) {
+		s.fallback = func(key string) (string, bool) {
+			for _, src := range sources {
+				if value, ok := src.Lookup(key); ok {
+					return value, true
+				}
+			}
+			return "", false
+		}
+	}
+}
+
+type dotenvSource struct {
+	path string
+	dotenvConfig
+
+	mu     sync.RWMutex
+	values map[string]string
+	lines  map[string]int
+}
+
+// DotenvSource returns a Source backed by the dotenv file at path, parsed
+// like the files given to WithEnvFiles. Malformed lines are skipped unless
+// DotenvStrict is given.
+func DotenvSource(path string, opts ...DotenvOption) Source {
+	s := &dotenvSource{path: path}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *dotenvSource) Name() string {
+	return s.path
+}
+
+func (s *dotenvSource) Lookup(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+func (s *dotenvSource) Refresh() error {
+	values, lines, err := readEnvFile(s.path, s.dotenvConfig)
+	var syntaxErr *DotenvSyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load %s file: %w", s.path, err)
+	}
+	s.mu.Lock()
+	s.values, s.lines = values, lines
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *dotenvSource) watchPaths() []string {
+	return []string{s.path}
+}
+
+// line returns the line of the file that set key, or 0 if it is not set.
+func (s *dotenvSource) line(key string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lines[key]
+}
+
+// lookupSources returns the first value for a field found in sources, and
+// the source it came from. Flat sources are asked for key; structured
+// sources for keys, the field's path.
+func lookupSources(sources []Source, key string, keys []string) (any, Source, bool) {
+	for _, src := range sources {
+		if ts, ok := src.(treeSource); ok {
+			if value, ok := ts.lookupPath(keys); ok {
+				return value, src, true
+			}
+			continue
+		}
+		if value, ok := src.Lookup(key); ok {
+			return value, src, true
+		}
+	}
+	return nil, nil, false
+}
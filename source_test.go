@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type SourceConfig struct {
+	Host string `env:"SOURCE_HOST"`
+	Port int    `env:"SOURCE_PORT"`
+	User string `env:"SOURCE_USER"`
+}
+
+func (c *SourceConfig) SetDefaults() {
+	c.Host = "localhost"
+	c.Port = 8080
+	c.User = "default"
+}
+
+func TestSourcesAreLayeredInOrder(t *testing.T) {
+	dotenv := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(dotenv, []byte("SOURCE_HOST=dotenv\nSOURCE_PORT=3000\n"), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", dotenv, err)
+	}
+
+	os.Setenv("SOURCE_HOST", "env")
+	defer os.Unsetenv("SOURCE_HOST")
+
+	loader := New(
+		WithoutEnvMutation(),
+		WithSources(
+			MapSource(map[string]string{"SOURCE_PORT": "9000"}),
+			EnvSource(),
+			DotenvSource(dotenv),
+		),
+	)
+
+	var cfg SourceConfig
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Host != "env" {
+		t.Errorf("expected Host to be 'env', got '%s'", cfg.Host)
+	}
+	if cfg.Port != 9000 {
+		t.Errorf("expected Port to be 9000 (from map), got %d", cfg.Port)
+	}
+	if cfg.User != "default" {
+		t.Errorf("expected User to be 'default', got '%s'", cfg.User)
+	}
+	if _, exists := os.LookupEnv("SOURCE_PORT"); exists {
+		t.Error("expected SOURCE_PORT not to be set in the process environment")
+	}
+}
+
+func TestDotenvSourceIsRefreshedOnLoad(t *testing.T) {
+	dotenv := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(dotenv, []byte("SOURCE_PORT=3000\n"), 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", dotenv, err)
+	}
+
+	loader := New(WithoutEnvMutation(), WithSources(DotenvSource(dotenv)))
+
+	var cfg SourceConfig
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.Port != 3000 {
+		t.Errorf("expected Port to be 3000, got %d", cfg.Port)
+	}
+
+	if err := os.WriteFile(dotenv, []byte("SOURCE_PORT=4000\n"), 0644); err != nil {
+		t.Fatalf("Failed to update %s: %v", dotenv, err)
+	}
+	if err := loader.Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.Port != 4000 {
+		t.Errorf("expected Port to be 4000 after reload, got %d", cfg.Port)
+	}
+}
@@ -0,0 +1,530 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TOMLSource returns a Source backed by the TOML file at path. Tables map
+// onto nested structs as described for WithFileSources.
+//
+// Scalars are decoded as strings and parsed according to the field they are
+// bound to, so local dates and times can be bound to time.Time fields with a
+// layout tag.
+func TOMLSource(path string) Source {
+	return &fileSource{path: path, parse: parseTOML}
+}
+
+var (
+	tomlBareKey  = regexp.MustCompile(`^[A-Za-z0-9_-]+`)
+	tomlInteger  = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	tomlHex      = regexp.MustCompile(`^0x[0-9A-Fa-f](_?[0-9A-Fa-f])*$`)
+	tomlOctal    = regexp.MustCompile(`^0o[0-7](_?[0-7])*$`)
+	tomlBinary   = regexp.MustCompile(`^0b[01](_?[01])*$`)
+	tomlFloat    = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)((\.[0-9](_?[0-9])*)([eE][+-]?[0-9](_?[0-9])*)?|[eE][+-]?[0-9](_?[0-9])*)$`)
+	tomlSpecial  = regexp.MustCompile(`^[+-]?(inf|nan)$`)
+	tomlDateTime = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[+-]\d{2}:\d{2})?)?$|^\d{2}:\d{2}(:\d{2}(\.\d+)?)?$`)
+)
+
+type tomlParser struct {
+	data string
+	pos  int
+	root map[string]any
+	// current is the table that key/value pairs are added to
+	current map[string]any
+	// defined holds the headers of tables defined so far
+	defined map[string]bool
+}
+
+func parseTOML(data []byte) (map[string]any, error) {
+	p := &tomlParser{
+		data:    strings.TrimPrefix(string(data), "\ufeff"),
+		root:    map[string]any{},
+		defined: map[string]bool{},
+	}
+	p.current = p.root
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			return p.root, nil
+		}
+		var err error
+		if p.data[p.pos] == '[' {
+			err = p.parseTable()
+		} else {
+			err = p.parseKeyValue(p.current)
+		}
+		if err == nil {
+			err = p.parseLineEnd()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *tomlParser) errorAt(pos int, reason string) error {
+	line, column := position(p.data, pos)
+	return &SyntaxError{Line: line, Column: column, Reason: reason}
+}
+
+func (p *tomlParser) peek(s string) bool {
+	return strings.HasPrefix(p.data[p.pos:], s)
+}
+
+// skipSpace skips spaces and tabs.
+func (p *tomlParser) skipSpace() {
+	for p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, line breaks and comments.
+func (p *tomlParser) skipBlank() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	if end := strings.IndexByte(p.data[p.pos:], '\n'); end >= 0 {
+		p.pos += end
+	} else {
+		p.pos = len(p.data)
+	}
+}
+
+// parseLineEnd consumes an optional comment and the line break ending a
+// statement.
+func (p *tomlParser) parseLineEnd() error {
+	p.skipSpace()
+	if p.peek("#") {
+		p.skipComment()
+	}
+	switch {
+	case p.pos >= len(p.data):
+		return nil
+	case p.peek("\n"):
+		p.pos++
+		return nil
+	case p.peek("\r\n"):
+		p.pos += 2
+		return nil
+	}
+	return p.errorAt(p.pos, "expected end of line")
+}
+
+// parseTable parses a [table] or [[array.of.tables]] header and makes it
+// the current table.
+func (p *tomlParser) parseTable() error {
+	start := p.pos
+	array := p.peek("[[")
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+	p.skipSpace()
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if array && !p.peek("]]") || !array && !p.peek("]") {
+		return p.errorAt(p.pos, "expected ']' to close table header")
+	}
+	if array {
+		p.pos += 2
+	} else {
+		p.pos++
+	}
+
+	table := p.root
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.descend(table, key, start); err != nil {
+			return err
+		}
+	}
+
+	header := strings.Join(keys, "\x00")
+	last := keys[len(keys)-1]
+	existing, exists := table[last]
+	if array {
+		list, ok := existing.([]any)
+		if exists && !ok {
+			return p.errorAt(start, "key '"+last+"' is already defined")
+		}
+		next := map[string]any{}
+		table[last] = append(list, next)
+		p.current = next
+		// tables below a new array element may be defined again
+		for defined := range p.defined {
+			if strings.HasPrefix(defined, header+"\x00") {
+				delete(p.defined, defined)
+			}
+		}
+		return nil
+	}
+
+	if p.defined[header] {
+		return p.errorAt(start, "table '"+strings.Join(keys, ".")+"' is already defined")
+	}
+	p.defined[header] = true
+	if !exists {
+		next := map[string]any{}
+		table[last] = next
+		p.current = next
+		return nil
+	}
+	next, ok := existing.(map[string]any)
+	if !ok {
+		return p.errorAt(start, "key '"+last+"' is already defined")
+	}
+	p.current = next
+	return nil
+}
+
+// descend returns the table named key within table, creating it if needed.
+// For an array of tables it returns the last element.
+func (p *tomlParser) descend(table map[string]any, key string, pos int) (map[string]any, error) {
+	switch next := table[key].(type) {
+	case nil:
+		created := map[string]any{}
+		table[key] = created
+		return created, nil
+	case map[string]any:
+		return next, nil
+	case []any:
+		if len(next) > 0 {
+			if last, ok := next[len(next)-1].(map[string]any); ok {
+				return last, nil
+			}
+		}
+	}
+	return nil, p.errorAt(pos, "key '"+key+"' is already defined")
+}
+
+// parseKeyValue parses "key = value" into table. Dotted keys create nested
+// tables.
+func (p *tomlParser) parseKeyValue(table map[string]any) error {
+	start := p.pos
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if !p.peek("=") {
+		return p.errorAt(p.pos, "expected '=' after key")
+	}
+	p.pos++
+	p.skipSpace()
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys[:len(keys)-1] {
+		if table, err = p.descend(table, key, start); err != nil {
+			return err
+		}
+	}
+	last := keys[len(keys)-1]
+	if _, exists := table[last]; exists {
+		return p.errorAt(start, "duplicate key '"+strings.Join(keys, ".")+"'")
+	}
+	table[last] = value
+	return nil
+}
+
+// parseKey parses a bare, quoted or dotted key.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+	for {
+		var key string
+		switch {
+		case p.peek(`"`):
+			s, err := p.parseBasicString()
+			if err != nil {
+				return nil, err
+			}
+			key = s
+		case p.peek("'"):
+			s, err := p.parseLiteralString()
+			if err != nil {
+				return nil, err
+			}
+			key = s
+		default:
+			key = tomlBareKey.FindString(p.data[p.pos:])
+			if key == "" {
+				return nil, p.errorAt(p.pos, "expected a key")
+			}
+			p.pos += len(key)
+		}
+		keys = append(keys, key)
+
+		p.skipSpace()
+		if !p.peek(".") {
+			return keys, nil
+		}
+		p.pos++
+		p.skipSpace()
+	}
+}
+
+func (p *tomlParser) parseValue() (any, error) {
+	switch {
+	case p.pos >= len(p.data):
+		return nil, p.errorAt(p.pos, "expected a value")
+	case p.peek(`"""`):
+		return p.parseMultilineString(`"""`)
+	case p.peek("'''"):
+		return p.parseMultilineString("'''")
+	case p.peek(`"`):
+		return p.parseBasicString()
+	case p.peek("'"):
+		return p.parseLiteralString()
+	case p.peek("["):
+		return p.parseArray()
+	case p.peek("{"):
+		return p.parseInlineTable()
+	}
+	return p.parseScalar()
+}
+
+func (p *tomlParser) parseArray() (any, error) {
+	start := p.pos
+	p.pos++
+	items := []any{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.data) {
+			return nil, p.errorAt(start, "unterminated array")
+		}
+		if p.peek("]") {
+			p.pos++
+			return items, nil
+		}
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		p.skipBlank()
+		switch {
+		case p.peek(","):
+			p.pos++
+		case p.peek("]"):
+		default:
+			return nil, p.errorAt(p.pos, "expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (any, error) {
+	p.pos++
+	table := map[string]any{}
+	p.skipSpace()
+	if p.peek("}") {
+		p.pos++
+		return table, nil
+	}
+	for {
+		p.skipSpace()
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch {
+		case p.peek(","):
+			p.pos++
+		case p.peek("}"):
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorAt(p.pos, "expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// parseScalar parses a boolean, number or date/time, returning it in a form
+// that setScalar accepts: integers in decimal, without underscores.
+func (p *tomlParser) parseScalar() (any, error) {
+	start := p.pos
+	end := start
+	for end < len(p.data) && !strings.ContainsRune(" \t\r\n,]}#", rune(p.data[end])) {
+		end++
+	}
+	// a date and time may be separated by a space
+	if end+3 < len(p.data) && p.data[end] == ' ' && tomlDateTime.MatchString(p.data[start:end]) &&
+		isDigit(p.data[end+1]) && isDigit(p.data[end+2]) && p.data[end+3] == ':' {
+		next := end + 1
+		for next < len(p.data) && !strings.ContainsRune(" \t\r\n,]}#", rune(p.data[next])) {
+			next++
+		}
+		end = next
+	}
+	token := p.data[start:end]
+	p.pos = end
+
+	switch {
+	case token == "true" || token == "false":
+		return token, nil
+	case tomlInteger.MatchString(token):
+		return strings.ReplaceAll(token, "_", ""), nil
+	case tomlHex.MatchString(token), tomlOctal.MatchString(token), tomlBinary.MatchString(token):
+		n, err := strconv.ParseInt(strings.ReplaceAll(token, "_", ""), 0, 64)
+		if err != nil {
+			return nil, p.errorAt(start, "invalid integer '"+token+"'")
+		}
+		return strconv.FormatInt(n, 10), nil
+	case tomlFloat.MatchString(token), tomlSpecial.MatchString(token):
+		return strings.ReplaceAll(token, "_", ""), nil
+	case tomlDateTime.MatchString(token):
+		return strings.Replace(token, " ", "T", 1), nil
+	case token == "":
+		return nil, p.errorAt(start, "expected a value")
+	}
+	return nil, p.errorAt(start, "invalid value '"+token+"'")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.data) || p.data[p.pos] == '\n' {
+			return "", p.errorAt(start, errUnterminatedQuote.Error())
+		}
+		c := p.data[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.parseEscape(&b, false); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	start := p.pos
+	p.pos++
+	end := strings.IndexAny(p.data[p.pos:], "'\n")
+	if end < 0 || p.data[p.pos+end] != '\'' {
+		return "", p.errorAt(start, errUnterminatedQuote.Error())
+	}
+	s := p.data[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+// parseMultilineString parses a multi-line basic or literal string closed by
+// delim. A line break straight after the opening delimiter is dropped.
+func (p *tomlParser) parseMultilineString(delim string) (string, error) {
+	start := p.pos
+	p.pos += len(delim)
+	if p.peek("\r\n") {
+		p.pos += 2
+	} else if p.peek("\n") {
+		p.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.data) {
+			return "", p.errorAt(start, errUnterminatedQuote.Error())
+		}
+		if p.peek(delim) {
+			// up to two quotes may directly precede the closing delimiter
+			for i := 0; i < 2 && p.pos+len(delim) < len(p.data) && p.data[p.pos+len(delim)] == delim[0]; i++ {
+				b.WriteByte(delim[0])
+				p.pos++
+			}
+			p.pos += len(delim)
+			return b.String(), nil
+		}
+		c := p.data[p.pos]
+		if c == '\\' && delim == `"""` {
+			if err := p.parseEscape(&b, true); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+// parseEscape decodes the escape sequence at p.pos into b. In multi-line
+// strings a backslash at the end of a line trims the following whitespace.
+func (p *tomlParser) parseEscape(b *strings.Builder, multiline bool) error {
+	start := p.pos
+	p.pos++
+	if p.pos >= len(p.data) {
+		return p.errorAt(start, errInvalidEscape.Error())
+	}
+	c := p.data[p.pos]
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case 'e':
+		b.WriteByte(0x1b)
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return p.errorAt(start, errInvalidEscape.Error())
+		}
+		code, err := strconv.ParseUint(p.data[p.pos:p.pos+size], 16, 32)
+		if err != nil {
+			return p.errorAt(start, errInvalidEscape.Error())
+		}
+		b.WriteRune(rune(code))
+		p.pos += size
+	case ' ', '\t', '\r', '\n':
+		if !multiline {
+			return p.errorAt(start, errInvalidEscape.Error())
+		}
+		rest := p.data[start+1:]
+		trimmed := strings.TrimLeft(rest, " \t")
+		if !strings.HasPrefix(trimmed, "\n") && !strings.HasPrefix(trimmed, "\r\n") {
+			return p.errorAt(start, errInvalidEscape.Error())
+		}
+		p.pos = len(p.data) - len(strings.TrimLeft(rest, " \t\r\n"))
+	default:
+		return p.errorAt(start, errInvalidEscape.Error())
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTOML(t *testing.T) {
+	input := `# Application settings
+name = "toml-app" # trailing comment
+port = 8_080
+hex = 0xff
+ratio = 1.5e3
+debug = true
+path = 'C:\Users\app'
+motd = """
+Hello \
+   world"""
+raw = '''
+line one
+line two'''
+created = 1979-05-27 07:32:00Z
+origins = [
+  "a.example.com", # first
+  "b.example.com",
+]
+labels = { team = "core", tier = 1 }
+"quoted key" = "q"
+site.name = "dotted"
+
+[database]
+host = "db.internal"
+
+[database.options]
+sslmode = "require"
+
+[[servers]]
+name = "primary"
+
+[[servers]]
+name = "secondary"
+`
+	got, err := parseTOML([]byte(input))
+	if err != nil {
+		t.Fatalf("expected valid TOML, got error: %v", err)
+	}
+
+	expected := map[string]any{
+		"name":       "toml-app",
+		"port":       "8080",
+		"hex":        "255",
+		"ratio":      "1.5e3",
+		"debug":      "true",
+		"path":       `C:\Users\app`,
+		"motd":       "Hello world",
+		"raw":        "line one\nline two",
+		"created":    "1979-05-27T07:32:00Z",
+		"origins":    []any{"a.example.com", "b.example.com"},
+		"labels":     map[string]any{"team": "core", "tier": "1"},
+		"quoted key": "q",
+		"site":       map[string]any{"name": "dotted"},
+		"database": map[string]any{
+			"host":    "db.internal",
+			"options": map[string]any{"sslmode": "require"},
+		},
+		"servers": []any{
+			map[string]any{"name": "primary"},
+			map[string]any{"name": "secondary"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		for key, value := range expected {
+			if !reflect.DeepEqual(got[key], value) {
+				t.Errorf("expected %s to be %#v, got %#v", key, value, got[key])
+			}
+		}
+		if len(got) != len(expected) {
+			t.Errorf("expected %d keys, got %d: %v", len(expected), len(got), got)
+		}
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		input  string
+		line   int
+		column int
+	}{
+		{"missing equals", "name = 1\nport 8080\n", 2, 6},
+		{"duplicate key", "name = 1\nname = 2\n", 2, 1},
+		{"duplicate table", "[db]\n[db]\n", 2, 1},
+		{"invalid value", "port = 80a\n", 1, 8},
+		{"unterminated string", "name = \"abc\n", 1, 8},
+		{"invalid escape", "name = \"a\\qb\"\n", 1, 10},
+		{"trailing content", "name = \"a\" b\n", 1, 12},
+		{"unclosed header", "[db\nhost = 1\n", 1, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := parseTOML([]byte(tt.input))
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected *SyntaxError, got %T: %v", err, err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("expected error at %d:%d, got %d:%d (%s)", tt.line, tt.column, syntaxErr.Line, syntaxErr.Column, syntaxErr.Reason)
+			}
+		})
+	}
+}
+
+type TOMLConfig struct {
+	Name    string        `env:"TOML_NAME" json:"name"`
+	Timeout time.Duration `env:"TOML_TIMEOUT" json:"timeout"`
+	Ports   []int         `env:"TOML_PORTS" json:"ports"`
+	DB      struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	} `json:"database"`
+}
+
+func (c *TOMLConfig) SetDefaults() {
+}
+
+func TestTOMLSource(t *testing.T) {
+	path := writeTestFile(t, "config.toml", `name = "toml-app"
+timeout = "15s"
+ports = [80, 443]
+
+[database]
+HOST = "db.internal"
+port = 5432
+`)
+
+	var cfg TOMLConfig
+	if err := New(WithoutDotenv(), WithFileSources(TOMLSource(path))).Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Name != "toml-app" {
+		t.Errorf("expected Name to be 'toml-app', got '%s'", cfg.Name)
+	}
+	if cfg.Timeout != 15*time.Second {
+		t.Errorf("expected Timeout to be 15s, got %v", cfg.Timeout)
+	}
+	if expected := []int{80, 443}; !reflect.DeepEqual(cfg.Ports, expected) {
+		t.Errorf("expected Ports to be %v, got %v", expected, cfg.Ports)
+	}
+	if cfg.DB.Host != "db.internal" || cfg.DB.Port != 5432 {
+		t.Errorf("expected DB to be db.internal:5432, got %s:%d", cfg.DB.Host, cfg.DB.Port)
+	}
+}
+
+func TestTOMLSourceReportsFile(t *testing.T) {
+	path := writeTestFile(t, "config.toml", "name = \n")
+
+	var cfg TOMLConfig
+	err := New(WithoutDotenv(), WithFileSources(TOMLSource(path))).Load(&cfg)
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected *SyntaxError, got %T: %v", err, err)
+	}
+	if syntaxErr.File != path {
+		t.Errorf("expected File to be '%s', got '%s'", path, syntaxErr.File)
+	}
+}
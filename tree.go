@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// treeSource is implemented by sources holding structured data, such as
+// config files. Fields are looked up by path as described for
+// WithFileSources.
+type treeSource interface {
+	lookupPath(keys []string) (any, bool)
+}
+
+// fileSource is a Source backed by a structured config file that is re-read
+// on every Load; a missing file leaves it empty. parse must return a tree of
+// map[string]any, []any and string values.
+type fileSource struct {
+	path  string
+	parse func(data []byte) (map[string]any, error)
+
+	mu   sync.RWMutex
+	root map[string]any
+}
+
+func (s *fileSource) Name() string {
+	return s.path
+}
+
+// Lookup returns the top-level scalar named key.
+func (s *fileSource) Lookup(key string) (string, bool) {
+	value, ok := s.lookupPath([]string{key})
+	str, isString := value.(string)
+	return str, ok && isString
+}
+
+func (s *fileSource) lookupPath(keys []string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var node any = s.root
+	for _, key := range keys {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = lookupFold(m, key); !ok {
+			return nil, false
+		}
+	}
+	return treeValue(node)
+}
+
+func (s *fileSource) watchPaths() []string {
+	return []string{s.path}
+}
+
+func (s *fileSource) Refresh() error {
+	var root map[string]any
+	data, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load %s file: %w", s.path, err)
+	}
+	if err == nil {
+		if root, err = s.parse(data); err != nil {
+			var syntaxErr *SyntaxError
+			if errors.As(err, &syntaxErr) {
+				syntaxErr.File = s.path
+				return syntaxErr
+			}
+			return fmt.Errorf("failed to parse %s: %w", s.path, err)
+		}
+	}
+	s.mu.Lock()
+	s.root = root
+	s.mu.Unlock()
+	return nil
+}
+
+func lookupFold(m map[string]any, key string) (any, bool) {
+	if value, ok := m[key]; ok {
+		return value, true
+	}
+	for k, value := range m {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// treeValue converts a node into the form setRaw decodes. Sequences and
+// mappings of scalars become []string and map[string]string; anything deeper
+// is returned unchanged and rejected when decoded. A nil node is absent.
+func treeValue(node any) (any, bool) {
+	switch n := node.(type) {
+	case nil:
+		return nil, false
+	case []any:
+		items := make([]string, len(n))
+		for i, item := range n {
+			str, ok := item.(string)
+			if !ok {
+				return node, true
+			}
+			items[i] = str
+		}
+		return items, true
+	case map[string]any:
+		entries := make(map[string]string, len(n))
+		for key, value := range n {
+			str, ok := value.(string)
+			if !ok {
+				return node, true
+			}
+			entries[key] = str
+		}
+		return entries, true
+	default:
+		return node, true
+	}
+}
+
+// treeName returns the name of field within structured sources: its json
+// tag, or fallback. Embedded structs without a json tag have no name of
+// their own.
+func treeName(field reflect.StructField, fallback string) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch {
+	case name != "" && name != "-":
+		return name
+	case field.Anonymous:
+		return ""
+	default:
+		return fallback
+	}
+}
+
+func appendKey(keys []string, key string) []string {
+	return append(keys[:len(keys):len(keys)], key)
+}
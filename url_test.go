@@ -32,7 +32,7 @@ func TestURLValidatorInvalid(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for invalid URL, got nil")
 	}
-	expectedErr := "validation error: field 'URL' must be a valid URL"
+	expectedErr := "validation error: TEST_URL: field 'URL' must be a valid URL"
 	if err.Error() != expectedErr {
 		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
 	}
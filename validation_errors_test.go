@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+type MultiErrorConfig struct {
+	Name  string `env:"TEST_MULTI_NAME" validate:"required"`
+	Email string `env:"TEST_MULTI_EMAIL" validate:"email"`
+	DB    struct {
+		Port int `env:"PORT" validate:"min=1024"`
+	} `prefix:"TEST_MULTI_DB_"`
+}
+
+func (c *MultiErrorConfig) SetDefaults() {
+	c.Email = "invalid-email"
+	c.DB.Port = 80
+}
+
+func TestValidationErrorsCollectsAllFailures(t *testing.T) {
+	os.Unsetenv("TEST_MULTI_NAME")
+
+	var cfg MultiErrorConfig
+	err := Load(&cfg)
+	if err == nil {
+		t.Fatal("expected validation errors, got nil")
+	}
+
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	expected := []FieldError{
+		{Field: "Name", Key: "TEST_MULTI_NAME", Rule: "required", Message: "field 'Name' is required"},
+		{Field: "Email", Key: "TEST_MULTI_EMAIL", Rule: "email", Message: "field 'Email' must be a valid email address"},
+		{Field: "DB.Port", Key: "TEST_MULTI_DB_PORT", Rule: "min", Param: "1024", Message: "field 'Port' must be at least 1024"},
+	}
+	if len(validationErrs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len(validationErrs), validationErrs)
+	}
+	for i, fe := range validationErrs {
+		if fe != expected[i] {
+			t.Errorf("expected error %d to be %+v, got %+v", i, expected[i], fe)
+		}
+	}
+
+	expectedErr := "validation error: TEST_MULTI_NAME: field 'Name' is required; " +
+		"TEST_MULTI_EMAIL: field 'Email' must be a valid email address; " +
+		"TEST_MULTI_DB_PORT: field 'Port' must be at least 1024"
+	if err.Error() != expectedErr {
+		t.Errorf("expected error '%s', got '%s'", expectedErr, err.Error())
+	}
+}
@@ -14,38 +14,52 @@ func RegisterValidator(name string, fn ValidatorFunc) {
 	validators[name] = fn
 }
 
+// ValidateStruct checks every validate rule on s and its nested structs. All
+// failing rules are reported together as ValidationErrors.
 func ValidateStruct(s interface{}) error {
 	v := reflect.ValueOf(s)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
-	t := v.Type()
+	return New().validate(v)
+}
+
+// validateStruct appends a FieldError to errs for every failing rule. The
+// returned error is reserved for rules without a registered validator.
+func (l *Loader) validateStruct(v reflect.Value, errs *ValidationErrors) error {
+	return walkFields(v, l.prefix, func(fi fieldInfo) error {
+		return l.validateField(fi.field, fi.value, fi.path, fi.key, errs)
+	})
+}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		fieldValue := v.Field(i)
-		tag := field.Tag.Get("validate")
-		if tag == "" {
-			continue
+func (l *Loader) validateField(field reflect.StructField, fieldValue reflect.Value, path, key string, errs *ValidationErrors) error {
+	tag := field.Tag.Get("validate")
+	if tag == "" {
+		return nil
+	}
+	rules := strings.Split(tag, ",")
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		var ruleName, param string
+		if strings.Contains(rule, "=") {
+			parts := strings.SplitN(rule, "=", 2)
+			ruleName = parts[0]
+			param = parts[1]
+		} else {
+			ruleName = rule
+		}
+		validator, exists := l.validator(ruleName)
+		if !exists {
+			return fmt.Errorf("no validator registered for rule '%s'", ruleName)
 		}
-		rules := strings.Split(tag, ",")
-		for _, rule := range rules {
-			rule = strings.TrimSpace(rule)
-			var ruleName, param string
-			if strings.Contains(rule, "=") {
-				parts := strings.SplitN(rule, "=", 2)
-				ruleName = parts[0]
-				param = parts[1]
-			} else {
-				ruleName = rule
-			}
-			validator, exists := validators[ruleName]
-			if !exists {
-				return fmt.Errorf("no validator registered for rule '%s'", ruleName)
-			}
-			if err := validator(field, fieldValue, param); err != nil {
-				return err
-			}
+		if err := validator(field, fieldValue, param); err != nil {
+			*errs = append(*errs, FieldError{
+				Field:   path,
+				Key:     key,
+				Rule:    ruleName,
+				Param:   param,
+				Message: err.Error(),
+			})
 		}
 	}
 	return nil
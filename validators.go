@@ -1,6 +1,8 @@
 package config
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"net/mail"
 	"net/url"
@@ -8,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func init() {
@@ -31,43 +34,85 @@ func RequiredValidator(field reflect.StructField, value reflect.Value, param str
 }
 
 func MinValidator(field reflect.StructField, value reflect.Value, param string) error {
-	minVal, err := strconv.ParseInt(param, 10, 64)
+	order, err := compareToParam(field, value, param)
+	if errors.Is(err, errUnsupportedType) {
+		return fmt.Errorf("unsupported type for min validation: %s", value.Kind())
+	}
 	if err != nil {
 		return fmt.Errorf("invalid min value for field '%s'", field.Name)
 	}
-	switch value.Kind() {
-	case reflect.String:
-		if len(value.String()) < int(minVal) {
-			return fmt.Errorf("field '%s' must be at least %d characters", field.Name, minVal)
-		}
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if value.Int() < minVal {
-			return fmt.Errorf("field '%s' must be at least %d", field.Name, minVal)
+	if order < 0 {
+		if value.Kind() == reflect.String {
+			return fmt.Errorf("field '%s' must be at least %s characters", field.Name, param)
 		}
-	default:
-		return fmt.Errorf("unsupported type for min validation: %s", value.Kind())
+		return fmt.Errorf("field '%s' must be at least %s", field.Name, param)
 	}
 	return nil
 }
 
 func MaxValidator(field reflect.StructField, value reflect.Value, param string) error {
-	maxVal, err := strconv.ParseInt(param, 10, 64)
+	order, err := compareToParam(field, value, param)
+	if errors.Is(err, errUnsupportedType) {
+		return fmt.Errorf("unsupported type for max validation: %s", value.Kind())
+	}
 	if err != nil {
 		return fmt.Errorf("invalid max value for field '%s'", field.Name)
 	}
+	if order > 0 {
+		if value.Kind() == reflect.String {
+			return fmt.Errorf("field '%s' must be at most %s characters", field.Name, param)
+		}
+		return fmt.Errorf("field '%s' must be at most %s", field.Name, param)
+	}
+	return nil
+}
+
+// compareToParam compares value against param, parsed according to the
+// value's type, and returns -1, 0 or +1. Strings are compared by length.
+func compareToParam(field reflect.StructField, value reflect.Value, param string) (int, error) {
+	switch value.Type() {
+	case durationType:
+		d, err := time.ParseDuration(param)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(time.Duration(value.Int()), d), nil
+	case timeType:
+		t, err := time.Parse(tagOrDefault(field, "layout", time.RFC3339), param)
+		if err != nil {
+			return 0, err
+		}
+		return value.Interface().(time.Time).Compare(t), nil
+	}
+
 	switch value.Kind() {
 	case reflect.String:
-		if len(value.String()) > int(maxVal) {
-			return fmt.Errorf("field '%s' must be at most %d characters", field.Name, maxVal)
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return 0, err
 		}
+		return cmp.Compare(len(value.String()), n), nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if value.Int() > maxVal {
-			return fmt.Errorf("field '%s' must be at most %d", field.Name, maxVal)
+		n, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Int(), n), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(value.Uint(), n), nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return 0, err
 		}
+		return cmp.Compare(value.Float(), n), nil
 	default:
-		return fmt.Errorf("unsupported type for max validation: %s", value.Kind())
+		return 0, errUnsupportedType
 	}
-	return nil
 }
 
 func EmailValidator(field reflect.StructField, value reflect.Value, param string) error {
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// watchedSource is implemented by sources that read files, which Watch polls
+// for changes.
+type watchedSource interface {
+	watchPaths() []string
+}
+
+// Watch loads cfg like Load, then watches the dotenv files, config files and
+// directories it was loaded from until ctx is done. When they change, the
+// full load and validation is run again on a fresh T and onChange is called
+// with the previous and the new config. If the reload fails, the previous
+// config is kept and the error is passed to the handler set with
+// WithReloadErrorHandler. onChange is never called concurrently.
+//
+// Files are polled every WithWatchInterval and must be unchanged for
+// WithWatchDebounce before a reload. Dotenv values are never written to the
+// environment, since they would take precedence over later changes.
+func Watch[T DefaultSetter](ctx context.Context, cfg T, onChange func(old, new T), opts ...Option) error {
+	l := New(append(opts, WithoutEnvMutation())...)
+	if err := l.Load(cfg); err != nil {
+		return err
+	}
+	last := l.fingerprint()
+
+	go func() {
+		ticker := time.NewTicker(l.watchInterval)
+		defer ticker.Stop()
+
+		current := cfg
+		var changedAt time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if fp := l.fingerprint(); fp != last {
+					last, changedAt = fp, now
+					continue
+				}
+				if changedAt.IsZero() || now.Sub(changedAt) < l.watchDebounce {
+					continue
+				}
+				changedAt = time.Time{}
+
+				next := reflect.New(reflect.TypeOf(current).Elem()).Interface().(T)
+				if err := l.Load(next); err != nil {
+					l.onReloadError(err)
+					continue
+				}
+				if onChange != nil {
+					onChange(current, next)
+				}
+				current = next
+			}
+		}
+	}()
+	return nil
+}
+
+// fingerprint summarises the modification time and size of every file the
+// sources read from.
+func (l *Loader) fingerprint() string {
+	var b strings.Builder
+	for _, src := range l.sourceChain() {
+		ws, ok := src.(watchedSource)
+		if !ok {
+			continue
+		}
+		for _, path := range ws.watchPaths() {
+			info, err := os.Stat(path)
+			if err != nil {
+				fmt.Fprintf(&b, "%s: missing\n", path)
+				continue
+			}
+			fmt.Fprintf(&b, "%s: %d %d\n", path, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	return b.String()
+}
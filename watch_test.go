@@ -0,0 +1,120 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type WatchConfig struct {
+	LogLevel string `env:"WATCH_LOG_LEVEL" validate:"in=debug|info|warn"`
+	Port     int    `env:"WATCH_PORT"`
+}
+
+func (c *WatchConfig) SetDefaults() {
+	c.LogLevel = "info"
+	c.Port = 8080
+}
+
+// writeWatchedFile writes content to path and moves its modification time
+// forward, so that the change is seen regardless of timestamp resolution.
+func writeWatchedFile(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("Failed to set the modification time of %s: %v", path, err)
+	}
+}
+
+func TestWatchReloadsOnChange(t *testing.T) {
+	dotenv := filepath.Join(t.TempDir(), "app.env")
+	start := time.Now()
+	writeWatchedFile(t, dotenv, "WATCH_LOG_LEVEL=warn\n", start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type change struct{ old, new *WatchConfig }
+	changes := make(chan change, 1)
+	errs := make(chan error, 1)
+
+	cfg := &WatchConfig{}
+	err := Watch(ctx, cfg, func(old, new *WatchConfig) {
+		changes <- change{old, new}
+	},
+		WithEnvFiles(dotenv),
+		WithLookup(mapLookup(nil)),
+		WithWatchInterval(10*time.Millisecond),
+		WithWatchDebounce(20*time.Millisecond),
+		WithReloadErrorHandler(func(err error) { errs <- err }),
+	)
+	if err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Fatalf("expected LogLevel to be 'warn', got '%s'", cfg.LogLevel)
+	}
+	if _, exists := os.LookupEnv("WATCH_LOG_LEVEL"); exists {
+		t.Error("expected Watch not to set WATCH_LOG_LEVEL in the process environment")
+	}
+
+	writeWatchedFile(t, dotenv, "WATCH_LOG_LEVEL=debug\nWATCH_PORT=9000\n", start.Add(time.Second))
+	select {
+	case c := <-changes:
+		if c.old != cfg {
+			t.Error("expected the first change to report the original config as old")
+		}
+		if c.old.LogLevel != "warn" {
+			t.Errorf("expected old LogLevel to be 'warn', got '%s'", c.old.LogLevel)
+		}
+		if c.new.LogLevel != "debug" || c.new.Port != 9000 {
+			t.Errorf("expected new config to be debug on 9000, got %s on %d", c.new.LogLevel, c.new.Port)
+		}
+	case err := <-errs:
+		t.Fatalf("expected a reload, got error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a reload")
+	}
+
+	writeWatchedFile(t, dotenv, "WATCH_LOG_LEVEL=verbose\n", start.Add(2*time.Second))
+	select {
+	case c := <-changes:
+		t.Fatalf("expected an invalid config to be rejected, got %+v", c.new)
+	case err := <-errs:
+		if err == nil {
+			t.Error("expected a validation error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the reload error")
+	}
+
+	writeWatchedFile(t, dotenv, "WATCH_LOG_LEVEL=info\n", start.Add(3*time.Second))
+	select {
+	case c := <-changes:
+		if c.old.LogLevel != "debug" {
+			t.Errorf("expected the last valid config to be kept, got LogLevel '%s'", c.old.LogLevel)
+		}
+		if c.new.LogLevel != "info" {
+			t.Errorf("expected new LogLevel to be 'info', got '%s'", c.new.LogLevel)
+		}
+	case err := <-errs:
+		t.Fatalf("expected a reload, got error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a reload")
+	}
+}
+
+func TestWatchReturnsInitialLoadError(t *testing.T) {
+	cfg := &WatchConfig{}
+	err := Watch(context.Background(), cfg, nil,
+		WithoutDotenv(),
+		WithLookup(mapLookup(map[string]string{"WATCH_LOG_LEVEL": "verbose"})),
+	)
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+}
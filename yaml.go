@@ -0,0 +1,560 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// YAMLSource returns a Source backed by the YAML file at path. Mappings bind
+// to nested structs as described for WithFileSources.
+//
+// Only a subset of YAML is supported: block mappings and sequences, plain
+// and quoted scalars, flow sequences and mappings on a single line, literal
+// (|) and folded (>) block strings, and comments. Anchors, aliases, tags and
+// multiple documents are not.
+func YAMLSource(path string) Source {
+	return &fileSource{path: path, parse: parseYAML}
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(data []byte) (map[string]any, error) {
+	p := &yamlParser{}
+	doc := strings.TrimPrefix(string(data), "\ufeff")
+	for i, raw := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n") {
+		text := strings.TrimLeft(raw, " ")
+		line := yamlLine{
+			num:    i + 1,
+			indent: len(raw) - len(text),
+			text:   strings.TrimRight(text, " \t"),
+		}
+		if strings.HasPrefix(line.text, "\t") {
+			return nil, &SyntaxError{Line: line.num, Column: line.indent + 1, Reason: "tabs are not allowed in indentation"}
+		}
+		p.lines = append(p.lines, line)
+	}
+
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.lines[p.pos].text == "---" {
+		p.pos++
+	}
+	node, err := p.parseNode(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.lines[p.pos].text != "..." {
+		return nil, p.errorf(p.lines[p.pos], "unexpected content")
+	}
+	if node == nil {
+		return map[string]any{}, nil
+	}
+	root, ok := node.(map[string]any)
+	if !ok {
+		return nil, &SyntaxError{Line: 1, Column: 1, Reason: "document must be a mapping"}
+	}
+	return root, nil
+}
+
+// skipBlank advances past empty and comment-only lines.
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) {
+		text := p.lines[p.pos].text
+		if text != "" && !strings.HasPrefix(text, "#") {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *yamlParser) errorf(line yamlLine, reason string) error {
+	return &SyntaxError{Line: line.num, Column: line.indent + 1, Reason: reason}
+}
+
+// parseNode parses the block node starting at the next line, provided it is
+// indented by at least indent. It returns nil if there is no such node.
+func (p *yamlParser) parseNode(indent int) (any, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	line := p.lines[p.pos]
+	if line.indent < indent || line.text == "..." {
+		return nil, nil
+	}
+	if isSequenceItem(line.text) {
+		return p.parseSequence(line.indent)
+	}
+	return p.parseMapping(line.indent)
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseMapping(indent int) (map[string]any, error) {
+	m := map[string]any{}
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return m, nil
+		}
+		line := p.lines[p.pos]
+		if line.indent < indent || line.text == "..." {
+			return m, nil
+		}
+		if line.indent > indent {
+			return nil, p.errorf(line, "unexpected indentation")
+		}
+		if isSequenceItem(line.text) {
+			return nil, p.errorf(line, "unexpected sequence item in mapping")
+		}
+
+		key, rest, ok := splitYAMLKey(line.text)
+		if !ok {
+			return nil, p.errorf(line, "expected 'key: value'")
+		}
+		if _, exists := m[key]; exists {
+			return nil, p.errorf(line, "duplicate key '"+key+"'")
+		}
+		p.pos++
+
+		var value any
+		var err error
+		if rest == "" {
+			// a nested block, which may be a sequence at the key's own indent
+			value, err = p.parseNode(indent + 1)
+			if value == nil && err == nil && p.pos < len(p.lines) {
+				if next := p.lines[p.pos]; next.indent == indent && isSequenceItem(next.text) {
+					value, err = p.parseSequence(indent)
+				}
+			}
+		} else {
+			value, err = p.parseInline(rest, line, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]any, error) {
+	var items []any
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			return items, nil
+		}
+		line := p.lines[p.pos]
+		if line.indent != indent || !isSequenceItem(line.text) {
+			if line.indent > indent {
+				return nil, p.errorf(line, "unexpected indentation")
+			}
+			return items, nil
+		}
+
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		var item any
+		var err error
+		switch {
+		case rest == "" || strings.HasPrefix(rest, "#"):
+			p.pos++
+			item, err = p.parseNode(indent + 1)
+		case isSequenceItem(rest) || isYAMLMappingEntry(rest):
+			// a nested node starting on the item's line: reparse the rest
+			// of the line as if it started at its own column
+			p.lines[p.pos] = yamlLine{
+				num:    line.num,
+				indent: line.indent + len(line.text) - len(rest),
+				text:   rest,
+			}
+			item, err = p.parseNode(indent + 1)
+		default:
+			p.pos++
+			item, err = p.parseInline(rest, line, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+// parseInline parses the value following "key:" or "- " on line. indent is
+// the indent of the line's parent node, which block strings must exceed.
+func (p *yamlParser) parseInline(text string, line yamlLine, indent int) (any, error) {
+	switch text[0] {
+	case '|', '>':
+		return p.parseBlockString(text, line, indent)
+	case '[', '{':
+		fp := &yamlFlowParser{text: stripYAMLComment(text)}
+		value, err := fp.parseValue()
+		if err == nil {
+			fp.skipSpace()
+			if fp.pos < len(fp.text) {
+				err = fp.errorf("unexpected content after flow collection")
+			}
+		}
+		if err != nil {
+			column := line.indent + len(line.text) - len(text) + fp.pos + 1
+			return nil, &SyntaxError{Line: line.num, Column: column, Reason: err.Error()}
+		}
+		return value, nil
+	}
+	value, err := parseYAMLScalar(stripYAMLComment(text))
+	if err != nil {
+		return nil, p.errorf(line, err.Error())
+	}
+	return value, nil
+}
+
+// parseBlockString parses a literal (|) or folded (>) block string whose
+// header is text, with an optional chomping indicator (- or +) and an
+// optional explicit indentation digit.
+func (p *yamlParser) parseBlockString(text string, line yamlLine, indent int) (any, error) {
+	header := stripYAMLComment(text)
+	folded := header[0] == '>'
+	chomp := byte(0)
+	blockIndent := 0
+	for _, c := range []byte(header[1:]) {
+		switch {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = c
+		case c >= '1' && c <= '9' && blockIndent == 0:
+			blockIndent = indent + int(c-'0')
+		default:
+			return nil, p.errorf(line, "invalid block string header '"+header+"'")
+		}
+	}
+
+	var content []string
+	for ; p.pos < len(p.lines); p.pos++ {
+		l := p.lines[p.pos]
+		if l.text == "" {
+			content = append(content, "")
+			continue
+		}
+		if blockIndent == 0 {
+			if l.indent <= indent {
+				break
+			}
+			blockIndent = l.indent
+		}
+		if l.indent < blockIndent {
+			break
+		}
+		content = append(content, strings.Repeat(" ", l.indent-blockIndent)+l.text)
+	}
+
+	// trailing blank lines belong to the chomping, not the content
+	trailing := 0
+	for len(content) > 0 && content[len(content)-1] == "" {
+		content = content[:len(content)-1]
+		trailing++
+	}
+
+	var b strings.Builder
+	for i, c := range content {
+		if i > 0 {
+			prev := content[i-1]
+			switch {
+			case !folded:
+				b.WriteByte('\n')
+			case c == "":
+				// each blank line folds into a single line break
+				b.WriteByte('\n')
+			case prev == "":
+			case strings.HasPrefix(c, " ") || strings.HasPrefix(prev, " "):
+				// more indented lines are not folded
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(c)
+	}
+
+	if len(content) > 0 {
+		switch chomp {
+		case '-':
+			// strip: no trailing line break
+		case '+':
+			b.WriteString(strings.Repeat("\n", trailing+1))
+		default:
+			b.WriteByte('\n')
+		}
+	}
+	return b.String(), nil
+}
+
+// splitYAMLKey splits a "key: value" line, unquoting the key.
+func splitYAMLKey(text string) (string, string, bool) {
+	var key string
+	var rest string
+	if text[0] == '"' || text[0] == '\'' {
+		end := closingQuote(text)
+		if end < 0 {
+			return "", "", false
+		}
+		unquoted, err := parseYAMLScalar(text[:end+1])
+		if err != nil {
+			return "", "", false
+		}
+		key, _ = unquoted.(string)
+		rest = text[end+1:]
+		if !strings.HasPrefix(rest, ":") || (len(rest) > 1 && rest[1] != ' ') {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			key = strings.TrimSpace(text[:i])
+			rest = strings.TrimSpace(text[i+1:])
+			if key == "" || strings.HasPrefix(key, "#") {
+				return "", "", false
+			}
+			if strings.HasPrefix(rest, "#") {
+				rest = ""
+			}
+			return key, rest, true
+		}
+		if text[i] == ' ' && i+1 < len(text) && text[i+1] == '#' {
+			break
+		}
+	}
+	return "", "", false
+}
+
+func isYAMLMappingEntry(text string) bool {
+	_, _, ok := splitYAMLKey(text)
+	return ok
+}
+
+// closingQuote returns the index of the quote closing the quoted scalar at
+// the start of text, or -1.
+func closingQuote(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment removes a trailing comment, which starts with a # that is
+// preceded by whitespace and not inside quotes.
+func stripYAMLComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" [{,:", rune(text[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return strings.TrimRight(text[:i], " \t")
+		}
+	}
+	return text
+}
+
+// parseYAMLScalar parses a plain or quoted scalar. Plain scalars are kept as
+// strings, except null and ~ which are nil.
+func parseYAMLScalar(text string) (any, error) {
+	if text == "" || text == "~" || text == "null" || text == "Null" || text == "NULL" {
+		return nil, nil
+	}
+	switch text[0] {
+	case '"':
+		if closingQuote(text) != len(text)-1 {
+			return nil, errUnterminatedQuote
+		}
+		return unquoteYAMLDouble(text[1 : len(text)-1])
+	case '\'':
+		if closingQuote(text) != len(text)-1 {
+			return nil, errUnterminatedQuote
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	return text, nil
+}
+
+func unquoteYAMLDouble(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i == len(s) {
+			return "", errInvalidEscape
+		}
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '0':
+			b.WriteByte(0)
+		case '"', '\\', '/', ' ':
+			b.WriteByte(s[i])
+		case 'x', 'u', 'U':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[s[i]]
+			if i+size >= len(s) {
+				return "", errInvalidEscape
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil {
+				return "", errInvalidEscape
+			}
+			b.WriteRune(rune(code))
+			i += size
+		default:
+			return "", errInvalidEscape
+		}
+	}
+	return b.String(), nil
+}
+
+// yamlFlowParser parses a single-line flow collection such as [a, b] or
+// {key: value}.
+type yamlFlowParser struct {
+	text string
+	pos  int
+}
+
+func (fp *yamlFlowParser) errorf(reason string) error {
+	return errors.New(reason)
+}
+
+func (fp *yamlFlowParser) skipSpace() {
+	for fp.pos < len(fp.text) && fp.text[fp.pos] == ' ' {
+		fp.pos++
+	}
+}
+
+func (fp *yamlFlowParser) parseValue() (any, error) {
+	fp.skipSpace()
+	if fp.pos >= len(fp.text) {
+		return nil, fp.errorf("unexpected end of flow collection")
+	}
+	switch fp.text[fp.pos] {
+	case '[':
+		fp.pos++
+		var items []any
+		for {
+			fp.skipSpace()
+			if fp.pos < len(fp.text) && fp.text[fp.pos] == ']' {
+				fp.pos++
+				return items, nil
+			}
+			item, err := fp.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			if err := fp.parseSeparator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		fp.pos++
+		m := map[string]any{}
+		for {
+			fp.skipSpace()
+			if fp.pos < len(fp.text) && fp.text[fp.pos] == '}' {
+				fp.pos++
+				return m, nil
+			}
+			key, err := fp.parseScalar(true)
+			if err != nil {
+				return nil, err
+			}
+			fp.skipSpace()
+			if fp.pos >= len(fp.text) || fp.text[fp.pos] != ':' {
+				return nil, fp.errorf("expected ':' in flow mapping")
+			}
+			fp.pos++
+			value, err := fp.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			k, _ := key.(string)
+			m[k] = value
+			if err := fp.parseSeparator('}'); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return fp.parseScalar(false)
+	}
+}
+
+// parseSeparator consumes the comma between entries, leaving the closing
+// bracket for the caller.
+func (fp *yamlFlowParser) parseSeparator(closing byte) error {
+	fp.skipSpace()
+	if fp.pos < len(fp.text) {
+		switch fp.text[fp.pos] {
+		case ',':
+			fp.pos++
+			return nil
+		case closing:
+			return nil
+		}
+	}
+	return fp.errorf("expected ',' or '" + string(closing) + "'")
+}
+
+func (fp *yamlFlowParser) parseScalar(isKey bool) (any, error) {
+	fp.skipSpace()
+	start := fp.pos
+	if fp.pos < len(fp.text) && (fp.text[fp.pos] == '"' || fp.text[fp.pos] == '\'') {
+		end := closingQuote(fp.text[fp.pos:])
+		if end < 0 {
+			return nil, fp.errorf(errUnterminatedQuote.Error())
+		}
+		fp.pos += end + 1
+		return parseYAMLScalar(fp.text[start:fp.pos])
+	}
+	for fp.pos < len(fp.text) {
+		c := fp.text[fp.pos]
+		if c == ',' || c == ']' || c == '}' || (isKey && c == ':') {
+			break
+		}
+		fp.pos++
+	}
+	return parseYAMLScalar(strings.TrimSpace(fp.text[start:fp.pos]))
+}
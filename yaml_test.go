@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseYAML(t *testing.T) {
+	input := `---
+# Application settings
+name: yaml-app   # trailing comment
+port: 8080
+quoted: "a \"quoted\" # value"
+single: 'it''s'
+empty:
+nothing: ~
+url: http://example.com/path
+database:
+  host: db.internal
+  options:
+    sslmode: require
+origins:
+  - a.example.com
+  - "b.example.com"
+ports:
+- 80
+- 443
+flow: [one, "two, three", four]
+labels: {team: core, tier: "1"}
+servers:
+  - name: primary
+    weight: 10
+  - name: secondary
+literal: |
+  line one
+    indented
+  line three
+folded: >-
+  folded
+  text
+
+  new paragraph
+kept: |+
+  keep
+
+after: done
+`
+	got, err := parseYAML([]byte(input))
+	if err != nil {
+		t.Fatalf("expected valid YAML, got error: %v", err)
+	}
+
+	expected := map[string]any{
+		"name":    "yaml-app",
+		"port":    "8080",
+		"quoted":  `a "quoted" # value`,
+		"single":  "it's",
+		"empty":   nil,
+		"nothing": nil,
+		"url":     "http://example.com/path",
+		"database": map[string]any{
+			"host":    "db.internal",
+			"options": map[string]any{"sslmode": "require"},
+		},
+		"origins": []any{"a.example.com", "b.example.com"},
+		"ports":   []any{"80", "443"},
+		"flow":    []any{"one", "two, three", "four"},
+		"labels":  map[string]any{"team": "core", "tier": "1"},
+		"servers": []any{
+			map[string]any{"name": "primary", "weight": "10"},
+			map[string]any{"name": "secondary"},
+		},
+		"literal": "line one\n  indented\nline three\n",
+		"folded":  "folded text\nnew paragraph",
+		"kept":    "keep\n\n",
+		"after":   "done",
+	}
+	for key, value := range expected {
+		if !reflect.DeepEqual(got[key], value) {
+			t.Errorf("expected %s to be %#v, got %#v", key, value, got[key])
+		}
+	}
+	if len(got) != len(expected) {
+		t.Errorf("expected %d keys, got %d: %v", len(expected), len(got), got)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		input  string
+		line   int
+		column int
+	}{
+		{"missing colon", "name: ok\njust text\n", 2, 1},
+		{"duplicate key", "name: a\nname: b\n", 2, 1},
+		{"bad indentation", "name: a\n  port: 1\n", 2, 3},
+		{"tab indentation", "db:\n\thost: x\n", 2, 1},
+		{"unterminated quote", "name: \"abc\n", 1, 1},
+		{"unclosed flow", "list: [a, b\n", 1, 12},
+		{"not a mapping", "- a\n- b\n", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := parseYAML([]byte(tt.input))
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected *SyntaxError, got %T: %v", err, err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("expected error at %d:%d, got %d:%d (%s)", tt.line, tt.column, syntaxErr.Line, syntaxErr.Column, syntaxErr.Reason)
+			}
+		})
+	}
+}
+
+type YAMLConfig struct {
+	Name    string        `env:"YAML_NAME" json:"name"`
+	Timeout time.Duration `env:"YAML_TIMEOUT" json:"timeout"`
+	Origins []string      `env:"YAML_ORIGINS" json:"origins"`
+	DB      struct {
+		Host string `env:"HOST" json:"host"`
+		Port int    `env:"PORT" json:"port"`
+	} `json:"database"`
+}
+
+func (c *YAMLConfig) SetDefaults() {
+	c.DB.Port = 5432
+}
+
+func TestYAMLSource(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", `name: yaml-app
+timeout: 15s
+origins:
+  - a.example.com
+  - b.example.com
+database:
+  host: db.internal
+`)
+
+	var cfg YAMLConfig
+	if err := New(WithoutDotenv(), WithFileSources(YAMLSource(path))).Load(&cfg); err != nil {
+		t.Fatalf("expected valid config, got error: %v", err)
+	}
+
+	if cfg.Name != "yaml-app" {
+		t.Errorf("expected Name to be 'yaml-app', got '%s'", cfg.Name)
+	}
+	if cfg.Timeout != 15*time.Second {
+		t.Errorf("expected Timeout to be 15s, got %v", cfg.Timeout)
+	}
+	if expected := []string{"a.example.com", "b.example.com"}; !reflect.DeepEqual(cfg.Origins, expected) {
+		t.Errorf("expected Origins to be %v, got %v", expected, cfg.Origins)
+	}
+	if cfg.DB.Host != "db.internal" || cfg.DB.Port != 5432 {
+		t.Errorf("expected DB to be db.internal:5432, got %s:%d", cfg.DB.Host, cfg.DB.Port)
+	}
+}